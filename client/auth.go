@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsignableBody is returned by HMACSigner for a streamed body, which cannot
+// be read to hash it without consuming it.
+var ErrUnsignableBody = errors.New("request body cannot be read again to sign it")
+
+// AuthProvider adds credentials to an outgoing request. It runs once per attempt,
+// right before the request is sent.
+type AuthProvider interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthFunc adapts a function to AuthProvider.
+type AuthFunc func(req *http.Request) error
+
+func (f AuthFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// WithAuth authenticates every request with provider. A provider that also has an
+// Invalidate method, such as *ClientCredentials, is invalidated on a 401 response.
+func WithAuth(provider AuthProvider) Option {
+	return WithInterceptors(func(req *http.Request, next Handler) (*Response, error) {
+		if err := provider.Authenticate(req); err != nil {
+			return nil, err
+		}
+		resp, err := next(req)
+		if invalidator, ok := provider.(interface{ Invalidate() }); ok && err == nil && resp.StatusCode == http.StatusUnauthorized {
+			invalidator.Invalidate()
+		}
+		return resp, err
+	})
+}
+
+func BearerToken(token string) AuthProvider {
+	return AuthFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+func BasicAuth(username, password string) AuthProvider {
+	return AuthFunc(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// ClientCredentials fetches and caches an OAuth2 token with the client credentials
+// grant, refreshing it RefreshBefore its expiry (30s by default). A token request
+// taking longer than Timeout (30s by default) fails.
+type ClientCredentials struct {
+	TokenURL      string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+	RefreshBefore time.Duration
+	Timeout       time.Duration
+	Client        *HttpClient // client used for the token request, a plain one by default
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	refresh   *tokenRefresh // fetch in flight, shared by every caller waiting for a token
+}
+
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (c *ClientCredentials) Authenticate(req *http.Request) error {
+	token, err := c.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns the cached access token, fetching a new one when it is about to
+// expire. Concurrent callers share a single fetch, each waiting no longer than its
+// own ctx allows; the fetch itself is not cancelled when they give up, it only
+// stops at Timeout.
+func (c *ClientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	refreshBefore := c.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = 30 * time.Second
+	}
+	if c.token != "" && time.Now().Add(refreshBefore).Before(c.expiresAt) {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	refresh := c.refresh
+	if refresh == nil {
+		timeout := c.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		refresh = &tokenRefresh{done: make(chan struct{})}
+		c.refresh = refresh
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		go func() {
+			defer cancel()
+			c.refreshToken(fetchCtx, refresh)
+		}()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		return refresh.token, refresh.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (c *ClientCredentials) refreshToken(ctx context.Context, refresh *tokenRefresh) {
+	token, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.token = token.AccessToken
+		c.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		if token.ExpiresIn <= 0 {
+			c.expiresAt = time.Now().Add(time.Hour)
+		}
+		refresh.token = token.AccessToken
+	}
+	refresh.err = err
+	c.refresh = nil
+	c.mu.Unlock()
+	close(refresh.done)
+}
+
+// Invalidate drops the cached token so the next request fetches a new one.
+func (c *ClientCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
+
+func (c *ClientCredentials) fetch(ctx context.Context) (*tokenResponse, error) {
+	client := c.Client
+	if client == nil {
+		plain := NewHttpClient()
+		client = &plain
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	header := http.Header{"Accept": {contentTypeJSON}}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(url.QueryEscape(c.ClientID)+":"+url.QueryEscape(c.ClientSecret))))
+
+	token, err := PostJSON[url.Values, tokenResponse](ctx, client, c.TokenURL, form, header)
+	if err != nil {
+		return nil, fmt.Errorf("fetching oauth2 token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("fetching oauth2 token: empty access_token")
+	}
+	return token, nil
+}
+
+// HMACSigner signs requests with an HMAC over the method, path with query,
+// timestamp and the hex SHA-256 of the body, one per line:
+//
+//	Authorization: HMAC-SHA256 keyId=<KeyID>,signature=<base64 signature>
+//	X-Timestamp: <unix seconds>
+//	X-Content-Sha256: <hex body hash>
+//
+// A streamed body cannot be signed and fails with ErrUnsignableBody; send an
+// in-memory body such as a *bytes.Reader, or retry, which buffers the body.
+type HMACSigner struct {
+	KeyID  string
+	Secret []byte
+	Hash   func() hash.Hash // sha256.New by default
+	Now    func() time.Time // time.Now by default
+}
+
+func (s *HMACSigner) Authenticate(req *http.Request) error {
+	newHash, now := s.Hash, s.Now
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	body, err := signedBody(req)
+	if err != nil {
+		return err
+	}
+	bodyHash := sha256.Sum256(body)
+	contentHash := hex.EncodeToString(bodyHash[:])
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+
+	mac := hmac.New(newHash, s.Secret)
+	mac.Write([]byte(s.StringToSign(req.Method, req.URL.RequestURI(), timestamp, contentHash)))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Content-Sha256", contentHash)
+	req.Header.Set("Authorization", fmt.Sprintf("HMAC-SHA256 keyId=%s,signature=%s", s.KeyID, signature))
+	return nil
+}
+
+func (s *HMACSigner) StringToSign(method, requestURI, timestamp, contentHash string) string {
+	return strings.Join([]string{method, requestURI, timestamp, contentHash}, "\n")
+}
+
+// signedBody reads the body through GetBody, leaving req.Body for the transport.
+func signedBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, ErrUnsignableBody
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCredentials(t *testing.T) {
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokens++
+			id, secret, _ := r.BasicAuth()
+			assert.Equal(t, "id:secret", id+":"+secret)
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	provider := &ClientCredentials{TokenURL: server.URL + "/token", ClientID: "id", ClientSecret: "secret"}
+	c := NewHttpClient(WithAuth(provider))
+	for range 2 {
+		resp, err := c.Get(server.URL, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token", resp.StringBody())
+	}
+	assert.Equal(t, 1, tokens)
+}
+
+func TestHMACSigner(t *testing.T) {
+	secret := []byte("secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		hash := sha256.Sum256(body)
+		signer := &HMACSigner{}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signer.StringToSign(r.Method, r.URL.RequestURI(), r.Header.Get("X-Timestamp"), r.Header.Get("X-Content-Sha256"))))
+
+		assert.Equal(t, "1700000000", r.Header.Get("X-Timestamp"))
+		assert.Equal(t, hex.EncodeToString(hash[:]), r.Header.Get("X-Content-Sha256"))
+		assert.Equal(t, "HMAC-SHA256 keyId=key,signature="+base64.StdEncoding.EncodeToString(mac.Sum(nil)), r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	signer := &HMACSigner{KeyID: "key", Secret: secret, Now: func() time.Time { return time.Unix(1700000000, 0) }}
+	c := NewHttpClient(WithAuth(signer), WithRequestCompression(1))
+	resp, err := c.Post(server.URL+"/orders?id=1", map[string]string{"name": "huhx"}, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+}
+
+func TestHMACSigner_StreamedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	c := NewHttpClient(WithAuth(&HMACSigner{KeyID: "key", Secret: []byte("secret")}))
+	_, err := c.Post(server.URL, Raw("text/plain", io.MultiReader(strings.NewReader("hello"))), nil)
+	assert.ErrorIs(t, err, ErrUnsignableBody)
+}
+
+func TestClientCredentials_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	credentials := &ClientCredentials{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret", Timeout: 20 * time.Millisecond}
+	_, err := credentials.Token(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the hung fetch no longer blocks the next caller
+	_, err = credentials.Token(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientCredentials_SingleFlight(t *testing.T) {
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	credentials := &ClientCredentials{TokenURL: server.URL, ClientID: "id", ClientSecret: "secret"}
+	results := make(chan string, 3)
+	for range 3 {
+		go func() {
+			token, _ := credentials.Token(context.Background())
+			results <- token
+		}()
+	}
+
+	// a caller with a short deadline gives up without waiting for the slow endpoint
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := credentials.Token(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	for range 3 {
+		assert.Equal(t, "token", <-results)
+	}
+	assert.Equal(t, int32(1), fetches.Load())
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/goccy/go-json"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeForm   = "application/x-www-form-urlencoded"
+	contentTypeBinary = "application/octet-stream"
+)
+
+// RawBody sends Body as is with the given content type. The reader is streamed
+// unless the client has a retry policy, in which case it is read into memory so
+// that it can be sent again.
+type RawBody struct {
+	ContentType string
+	Body        io.Reader
+}
+
+func Raw(contentType string, body io.Reader) RawBody {
+	return RawBody{ContentType: contentType, Body: body}
+}
+
+// FilePart is a file uploaded in a multipart/form-data body. ContentType
+// defaults to application/octet-stream.
+type FilePart struct {
+	FieldName   string
+	FileName    string
+	ContentType string
+	Reader      io.Reader
+}
+
+// Multipart builds a multipart/form-data body, keeping the parts in the order they are added.
+type Multipart struct {
+	parts []multipartPart
+}
+
+type multipartPart struct {
+	name  string
+	value string
+	file  *FilePart
+}
+
+func NewMultipart() *Multipart {
+	return &Multipart{}
+}
+
+func (m *Multipart) Field(name, value string) *Multipart {
+	m.parts = append(m.parts, multipartPart{name: name, value: value})
+	return m
+}
+
+// File adds a file part. Bodies with files are streamed while the request is sent,
+// unless the client has a retry policy, in which case they are built in memory
+// so that they can be sent again.
+func (m *Multipart) File(fieldName, fileName string, reader io.Reader) *Multipart {
+	return m.FilePart(FilePart{FieldName: fieldName, FileName: fileName, Reader: reader})
+}
+
+func (m *Multipart) FilePart(part FilePart) *Multipart {
+	m.parts = append(m.parts, multipartPart{file: &part})
+	return m
+}
+
+// reader returns the encoded body, written by a goroutine while it is read when
+// there is a file part and built in memory otherwise.
+func (m *Multipart) reader() (io.Reader, string, error) {
+	boundary := multipart.NewWriter(nil).Boundary()
+	contentType := "multipart/form-data; boundary=" + boundary
+	write := func(w io.Writer) error {
+		return m.write(w, boundary)
+	}
+	for _, part := range m.parts {
+		if part.file != nil {
+			return newPipeBody(write), contentType, nil
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := write(&buffer); err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(buffer.Bytes()), contentType, nil
+}
+
+func (m *Multipart) write(w io.Writer, boundary string) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for _, part := range m.parts {
+		if part.file == nil {
+			if err := writer.WriteField(part.name, part.value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		contentType := part.file.ContentType
+		if contentType == "" {
+			contentType = contentTypeBinary
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(part.file.FieldName), escapeQuotes(part.file.FileName)))
+		header.Set("Content-Type", contentType)
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, part.file.Reader); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// encodePayload turns a request payload into its body and content type.
+// url.Values are sent as a url-encoded form, *Multipart as multipart/form-data,
+// RawBody, []byte and io.Reader as is. Anything else goes through the codec of
+// contentType, or is sent as JSON when no content type is given; a content type
+// without a registered codec is an ErrUnsupportedContentType. Only readers
+// supplied by the caller and multipart files come back as something other than
+// a *bytes.Reader.
+func encodePayload(payload interface{}, contentType string) (io.Reader, string, error) {
+	switch p := payload.(type) {
+	case url.Values:
+		return strings.NewReader(p.Encode()), contentTypeForm, nil
+	case *Multipart:
+		return p.reader()
+	case RawBody:
+		return p.Body, p.ContentType, nil
+	case []byte:
+		return bytes.NewReader(p), contentTypeBinary, nil
+	case io.Reader:
+		return p, contentTypeBinary, nil
+	default:
+		if contentType != "" {
+			codec, ok := CodecFor(contentType)
+			if !ok {
+				return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+			}
+			body, err := codec.Marshal(payload)
+			return bytes.NewReader(body), contentType, err
+		}
+		body, err := json.Marshal(payload)
+		return bytes.NewReader(body), contentTypeJSON, err
+	}
+}
+
+// inMemory reports whether body is already fully in memory, so reading it costs
+// nothing and the request can be replayed.
+func inMemory(body io.Reader) bool {
+	switch body.(type) {
+	case *bytes.Reader, *bytes.Buffer, *strings.Reader:
+		return true
+	}
+	return false
+}
+
+// pipeBody streams what write produces. The goroutine running write starts on
+// the first Read, so a body that is never sent leaks nothing.
+type pipeBody struct {
+	once  sync.Once
+	write func(w io.Writer) error
+	pr    *io.PipeReader
+	pw    *io.PipeWriter
+}
+
+func newPipeBody(write func(w io.Writer) error) *pipeBody {
+	pr, pw := io.Pipe()
+	return &pipeBody{write: write, pr: pr, pw: pw}
+}
+
+func (b *pipeBody) Read(p []byte) (int, error) {
+	b.once.Do(func() {
+		go func() {
+			b.pw.CloseWithError(b.write(b.pw))
+		}()
+	})
+	return b.pr.Read(p)
+}
+
+func (b *pipeBody) Close() error {
+	return b.pr.Close()
+}
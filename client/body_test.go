@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHttpClient_PostForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, contentTypeForm, r.Header.Get("Content-Type"))
+		assert.NoError(t, r.ParseForm())
+		_, _ = w.Write([]byte(r.PostForm.Get("name")))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	resp, err := c.Post(server.URL, url.Values{"name": {"huhx"}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "huhx", resp.StringBody())
+}
+
+func TestHttpClient_PostMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		file, header, err := r.FormFile("avatar")
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		_, _ = w.Write([]byte(r.FormValue("name") + "," + header.Filename + "," + string(content)))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	body := NewMultipart().Field("name", "huhx").File("avatar", "avatar.png", strings.NewReader("image"))
+	resp, err := c.Put(server.URL, body, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "huhx,avatar.png,image", resp.StringBody())
+}
+
+func TestHttpClient_PostRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Header.Get("Content-Type") + "," + string(content)))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	resp, err := c.Post(server.URL, Raw("text/csv", strings.NewReader("a,b")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv,a,b", resp.StringBody())
+
+	resp, err = c.Post(server.URL, []byte("raw"), http.Header{"Content-Type": {"text/plain"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain,raw", resp.StringBody())
+}
+
+func TestHttpClient_PostStreamed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			body, _ = gzip.NewReader(r.Body)
+		}
+		content, _ := io.ReadAll(body)
+		_, _ = w.Write([]byte(strconv.FormatInt(r.ContentLength, 10) + "," + string(content)))
+	}))
+	defer server.Close()
+
+	// a reader that is not in memory is sent chunked, without buffering it first
+	c := NewHttpClient()
+	resp, err := c.Post(server.URL, Raw("text/plain", io.MultiReader(strings.NewReader("a,b"))), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "-1,a,b", resp.StringBody())
+
+	c = NewHttpClient(WithRequestCompression(1024))
+	resp, err = c.Post(server.URL, io.MultiReader(strings.NewReader("compressed")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "-1,compressed", resp.StringBody())
+
+	// retries need the body again, so it is buffered
+	c = NewHttpClient(WithRetry(DefaultRetryPolicy()))
+	resp, err = c.Post(server.URL, io.MultiReader(strings.NewReader("a,b")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "3,a,b", resp.StringBody())
+}
+
+func TestMultipart_Reader(t *testing.T) {
+	fields, contentType, err := NewMultipart().Field("name", "huhx").reader()
+	assert.NoError(t, err)
+	assert.IsType(t, &bytes.Reader{}, fields)
+	assert.Contains(t, contentType, "multipart/form-data; boundary=")
+
+	files, _, err := NewMultipart().File("avatar", "avatar.png", strings.NewReader("image")).reader()
+	assert.NoError(t, err)
+	assert.IsType(t, &pipeBody{}, files)
+	content, err := io.ReadAll(files)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "image")
+}
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a stored response together with what is needed to decide
+// whether it can still be served.
+type CachedResponse struct {
+	Response  Response
+	Vary      http.Header // request headers named by Vary, as sent when the response was stored
+	ExpiresAt time.Time
+}
+
+// CacheStore keeps cached responses; implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, entry *CachedResponse)
+	Delete(key string)
+}
+
+// NewMemoryCacheStore returns an in-memory CacheStore holding at most maxEntries
+// responses, evicting the least recently used one first. Expired entries that
+// cannot be revalidated are dropped when they are read.
+func NewMemoryCacheStore(maxEntries int) CacheStore {
+	return &memoryCacheStore{
+		maxEntries: max(maxEntries, 1),
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+type memoryCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // most recently used first
+}
+
+type memoryCacheEntry struct {
+	key   string
+	entry *CachedResponse
+}
+
+func (s *memoryCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryCacheEntry).entry
+	if !entry.revalidatable() && !time.Now().Before(entry.ExpiresAt) {
+		s.remove(element)
+		return nil, false
+	}
+	s.order.MoveToFront(element)
+	return entry, true
+}
+
+func (s *memoryCacheStore) Set(key string, entry *CachedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*memoryCacheEntry).entry = entry
+		s.order.MoveToFront(element)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&memoryCacheEntry{key: key, entry: entry})
+	for s.order.Len() > s.maxEntries {
+		s.remove(s.order.Back())
+	}
+}
+
+func (s *memoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.entries[key]; ok {
+		s.remove(element)
+	}
+}
+
+func (s *memoryCacheStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.entries, element.Value.(*memoryCacheEntry).key)
+}
+
+// WithCache caches GET responses in cacheStore, see CacheInterceptor. The cache
+// runs after all other interceptors, whatever the order of the options, so it
+// sees the credentials they add; use CacheInterceptor to place it yourself.
+func WithCache(cacheStore CacheStore) Option {
+	return func(c *HttpClient) {
+		c.cache = CacheInterceptor(cacheStore)
+	}
+}
+
+// CacheInterceptor serves fresh GET responses from cacheStore and revalidates
+// stale ones with If-None-Match and If-Modified-Since. It honors max-age,
+// s-maxage, no-cache, no-store, private, Expires and Vary. The client is shared
+// by its callers, so responses to requests with Authorization or Cookie are only
+// stored and served when they are public or carry s-maxage.
+func CacheInterceptor(cacheStore CacheStore) Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		if req.Method != http.MethodGet || requestOptionsFrom(req.Context()).stream {
+			return next(req)
+		}
+		requestControl := parseCacheControl(req.Header)
+		if requestControl.has("no-store") {
+			return next(req)
+		}
+
+		key := req.URL.String()
+		credentialed := hasCredentials(req)
+		entry, ok := cacheStore.Get(key)
+		if ok && (!entry.matches(req) || (credentialed && !isShared(entry.Response.Header))) {
+			entry, ok = nil, false
+		}
+		if ok && !requestControl.has("no-cache") && time.Now().Before(entry.ExpiresAt) {
+			return entry.response(), nil
+		}
+
+		if ok {
+			if etag := entry.Response.Header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if modified := entry.Response.Header.Get("Last-Modified"); modified != "" && req.Header.Get("If-Modified-Since") == "" {
+				req.Header.Set("If-Modified-Since", modified)
+			}
+		}
+
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+		if ok && resp.StatusCode == http.StatusNotModified {
+			// entries may be read concurrently, so refresh a copy
+			refreshed := *entry
+			refreshed.Response.Header = entry.Response.Header.Clone()
+			for k, vs := range resp.Header {
+				refreshed.Response.Header[k] = vs
+			}
+			refreshed.ExpiresAt = expiresAt(refreshed.Response.Header)
+			cacheStore.Set(key, &refreshed)
+			return refreshed.response(), nil
+		}
+
+		if isCacheable(resp) && (!credentialed || isShared(resp.Header)) {
+			cacheStore.Set(key, newCachedResponse(req, resp))
+		} else if ok {
+			cacheStore.Delete(key)
+		}
+		return resp, nil
+	}
+}
+
+func newCachedResponse(req *http.Request, resp *Response) *CachedResponse {
+	vary := make(http.Header)
+	for _, name := range varyHeaders(resp.Header) {
+		vary[http.CanonicalHeaderKey(name)] = req.Header.Values(name)
+	}
+	return &CachedResponse{
+		Response: Response{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       bytes.Clone(resp.Body),
+		},
+		Vary:      vary,
+		ExpiresAt: expiresAt(resp.Header),
+	}
+}
+
+func (e *CachedResponse) matches(req *http.Request) bool {
+	for name, values := range e.Vary {
+		if strings.Join(req.Header.Values(name), ",") != strings.Join(values, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// revalidatable reports whether a stale entry can still be revalidated with the upstream.
+func (e *CachedResponse) revalidatable() bool {
+	return e.Response.Header.Get("ETag") != "" || e.Response.Header.Get("Last-Modified") != ""
+}
+
+func (e *CachedResponse) response() *Response {
+	return &Response{
+		Status:     e.Response.Status,
+		StatusCode: e.Response.StatusCode,
+		Header:     e.Response.Header.Clone(),
+		Body:       bytes.Clone(e.Response.Body),
+	}
+}
+
+func isCacheable(resp *Response) bool {
+	control := parseCacheControl(resp.Header)
+	if resp.StatusCode != http.StatusOK || control.has("no-store") || control.has("private") {
+		return false
+	}
+	for _, name := range varyHeaders(resp.Header) {
+		if name == "*" {
+			return false
+		}
+	}
+	return time.Now().Before(expiresAt(resp.Header)) ||
+		resp.Header.Get("ETag") != "" ||
+		resp.Header.Get("Last-Modified") != ""
+}
+
+func hasCredentials(req *http.Request) bool {
+	return req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != ""
+}
+
+// isShared reports whether a response may be given to other users than the one it was sent to.
+func isShared(header http.Header) bool {
+	control := parseCacheControl(header)
+	return control.has("public") || control.has("s-maxage")
+}
+
+// expiresAt computes the freshness lifetime from s-maxage or max-age, falling back to Expires.
+// A response without either, or with no-cache, is stale right away and must be revalidated.
+func expiresAt(header http.Header) time.Time {
+	now := time.Now()
+	control := parseCacheControl(header)
+	if control.has("no-cache") {
+		return now
+	}
+	maxAge, ok := control["s-maxage"]
+	if !ok {
+		maxAge, ok = control["max-age"]
+	}
+	if ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return now
+		}
+		age, _ := strconv.Atoi(header.Get("Age"))
+		return now.Add(time.Duration(seconds-age) * time.Second)
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires
+	}
+	return now
+}
+
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+type cacheControl map[string]string
+
+func parseCacheControl(header http.Header) cacheControl {
+	control := make(cacheControl)
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name != "" {
+				control[strings.ToLower(name)] = strings.Trim(arg, `"`)
+			}
+		}
+	}
+	return control
+}
+
+func (c cacheControl) has(directive string) bool {
+	_, ok := c[directive]
+	return ok
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_Cache(t *testing.T) {
+	hits, revalidations := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=60")
+		case "/etag":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				revalidations++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithCache(NewMemoryCacheStore(10)))
+	for _, path := range []string{"/fresh", "/etag", "/no-store"} {
+		for range 2 {
+			resp, err := c.Get(server.URL+path, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, path, resp.StringBody())
+		}
+	}
+
+	assert.Equal(t, 5, hits)
+	assert.Equal(t, 1, revalidations)
+}
+
+func TestHttpClient_CacheCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", r.URL.Query().Get("cache-control"))
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithCache(NewMemoryCacheStore(10)))
+	get := func(cacheControl, token string) string {
+		resp, err := c.Get(server.URL, map[string]string{"cache-control": cacheControl}, http.Header{"Authorization": {token}})
+		assert.NoError(t, err)
+		return resp.StringBody()
+	}
+
+	assert.Equal(t, "alice", get("max-age=60", "alice"))
+	assert.Equal(t, "bob", get("max-age=60", "bob"))
+
+	assert.Equal(t, "alice", get("public, max-age=60", "alice"))
+	assert.Equal(t, "alice", get("public, max-age=60", "bob"))
+
+	assert.Equal(t, "alice", get("s-maxage=60", "alice"))
+	assert.Equal(t, "alice", get("s-maxage=60", "bob"))
+}
+
+func TestHttpClient_CacheWithAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	store := NewMemoryCacheStore(10)
+	for name, options := range map[string][]Option{
+		"cache first": {WithCache(store), WithAuth(BearerToken("alice"))},
+		"auth first":  {WithAuth(BearerToken("alice")), WithCache(store)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			alice, bob := NewHttpClient(options...), NewHttpClient(WithCache(store), WithAuth(BearerToken("bob")))
+			resp, err := alice.Get(server.URL, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer alice", resp.StringBody())
+
+			resp, err = bob.Get(server.URL, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer bob", resp.StringBody())
+		})
+	}
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	cacheStore := NewMemoryCacheStore(2)
+	fresh := func() *CachedResponse {
+		return &CachedResponse{Response: Response{Header: http.Header{}}, ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	cacheStore.Set("a", fresh())
+	cacheStore.Set("b", fresh())
+	_, _ = cacheStore.Get("a")
+	cacheStore.Set("c", fresh())
+	_, ok := cacheStore.Get("b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+	_, ok = cacheStore.Get("a")
+	assert.True(t, ok)
+
+	expired := &CachedResponse{Response: Response{Header: http.Header{}}, ExpiresAt: time.Now()}
+	cacheStore.Set("expired", expired)
+	_, ok = cacheStore.Get("expired")
+	assert.False(t, ok)
+
+	expired.Response.Header.Set("ETag", `"v1"`)
+	cacheStore.Set("etag", expired)
+	_, ok = cacheStore.Get("etag")
+	assert.True(t, ok, "an expired entry with a validator is kept for revalidation")
+}
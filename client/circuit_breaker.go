@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/huhx/common-go/logger"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type BreakerState int
+
+const (
+	StateClosed BreakerState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// BreakerConfig configures a CircuitBreaker; zero fields take the defaults noted below.
+type BreakerConfig struct {
+	FailureRatio   float64       // failure ratio that opens the circuit, default 0.5
+	MinRequests    int           // requests needed in a window before the ratio is checked, default 20
+	Window         time.Duration // period after which the closed counters reset, default 1m
+	OpenDuration   time.Duration // time spent open before probing, default 30s
+	HalfOpenProbes int           // successful probes needed to close again, default 1
+	IsFailure      func(resp *Response, err error) bool
+	OnStateChange  func(host string, from, to BreakerState)
+}
+
+// CircuitOpenError is returned without sending the request while the circuit of Host is open.
+type CircuitOpenError struct {
+	Host  string
+	Until time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s until %s", e.Host, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Code() int {
+	return http.StatusServiceUnavailable
+}
+
+func (e *CircuitOpenError) Message() string {
+	return "Service Unavailable"
+}
+
+// CircuitBreaker keeps one circuit per upstream host.
+type CircuitBreaker struct {
+	config BreakerConfig
+	mu     sync.Mutex
+	hosts  map[string]*circuit
+}
+
+type circuit struct {
+	state     BreakerState
+	since     time.Time // start of the closed window or time the state was entered
+	requests  int
+	failures  int
+	inFlight  int // half-open probes currently running
+	successes int // successful half-open probes
+	// generation changes with every state change, so results of requests admitted
+	// in an earlier state are ignored
+	generation uint64
+}
+
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	if config.FailureRatio <= 0 {
+		config.FailureRatio = 0.5
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = 20
+	}
+	if config.Window <= 0 {
+		config.Window = time.Minute
+	}
+	if config.OpenDuration <= 0 {
+		config.OpenDuration = 30 * time.Second
+	}
+	if config.HalfOpenProbes <= 0 {
+		config.HalfOpenProbes = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreaker{config: config, hosts: make(map[string]*circuit)}
+}
+
+// WithCircuitBreaker guards every request with breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return WithInterceptors(breaker.Interceptor())
+}
+
+func (b *CircuitBreaker) Interceptor() Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		host := req.URL.Host
+		generation, err := b.allow(host)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next(req)
+		if errors.Is(err, context.Canceled) {
+			// the caller gave up, which says nothing about the upstream
+			b.release(host, generation)
+			return resp, err
+		}
+		b.record(host, generation, b.config.IsFailure(resp, err))
+		return resp, err
+	}
+}
+
+func (b *CircuitBreaker) State(host string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.hosts[host]; ok {
+		return c.state
+	}
+	return StateClosed
+}
+
+// allow admits a request and returns the generation of the circuit it was admitted in.
+func (b *CircuitBreaker) allow(host string) (uint64, error) {
+	b.mu.Lock()
+	c := b.circuit(host)
+	now := time.Now()
+
+	from := c.state
+	if c.state == StateOpen && now.Sub(c.since) >= b.config.OpenDuration {
+		c.reset(StateHalfOpen, now)
+	}
+	var err error
+	switch {
+	case c.state == StateOpen:
+		err = &CircuitOpenError{Host: host, Until: c.since.Add(b.config.OpenDuration)}
+	case c.state == StateHalfOpen && c.inFlight >= b.config.HalfOpenProbes:
+		err = &CircuitOpenError{Host: host, Until: now}
+	case c.state == StateHalfOpen:
+		c.inFlight++
+	}
+	to, generation := c.state, c.generation
+	b.mu.Unlock()
+
+	b.notify(host, from, to)
+	return generation, err
+}
+
+func (b *CircuitBreaker) record(host string, generation uint64, failed bool) {
+	b.mu.Lock()
+	c := b.circuit(host)
+	now := time.Now()
+	if generation != c.generation {
+		b.mu.Unlock()
+		return
+	}
+
+	from := c.state
+	switch c.state {
+	case StateClosed:
+		if now.Sub(c.since) >= b.config.Window {
+			c.reset(StateClosed, now)
+		}
+		c.requests++
+		if failed {
+			c.failures++
+		}
+		if failed && c.requests >= b.config.MinRequests && float64(c.failures)/float64(c.requests) >= b.config.FailureRatio {
+			c.reset(StateOpen, now)
+		}
+	case StateHalfOpen:
+		c.inFlight = max(c.inFlight-1, 0)
+		if failed {
+			c.reset(StateOpen, now)
+		} else if c.successes++; c.successes >= b.config.HalfOpenProbes {
+			c.reset(StateClosed, now)
+		}
+	}
+	to := c.state
+	b.mu.Unlock()
+
+	b.notify(host, from, to)
+}
+
+// release frees the probe slot of a request that ended without an outcome.
+func (b *CircuitBreaker) release(host string, generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(host)
+	if generation == c.generation && c.state == StateHalfOpen {
+		c.inFlight = max(c.inFlight-1, 0)
+	}
+}
+
+func (b *CircuitBreaker) circuit(host string) *circuit {
+	c, ok := b.hosts[host]
+	if !ok {
+		c = &circuit{since: time.Now()}
+		b.hosts[host] = c
+	}
+	return c
+}
+
+func (b *CircuitBreaker) notify(host string, from, to BreakerState) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(host, from, to)
+	}
+}
+
+func (c *circuit) reset(state BreakerState, now time.Time) {
+	generation := c.generation
+	if state != c.state {
+		generation++
+	}
+	*c = circuit{state: state, since: now, generation: generation}
+}
+
+// LogStateChange returns an OnStateChange callback that records transitions with l.
+func LogStateChange(l *logger.Logger) func(host string, from, to BreakerState) {
+	return func(host string, from, to BreakerState) {
+		fields := []zap.Field{zap.String("host", host), zap.Stringer("from", from), zap.Stringer("to", to)}
+		if to == StateOpen {
+			l.Warn("circuit breaker opened", fields...)
+		} else {
+			l.Info("circuit breaker state changed", fields...)
+		}
+	}
+}
+
+func defaultIsFailure(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.IsServerError()
+}
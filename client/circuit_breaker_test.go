@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	host := func() string { u, _ := url.Parse(server.URL); return u.Host }()
+
+	var transitions []BreakerState
+	breaker := NewCircuitBreaker(BreakerConfig{
+		MinRequests:  2,
+		OpenDuration: 20 * time.Millisecond,
+		OnStateChange: func(host string, from, to BreakerState) {
+			transitions = append(transitions, to)
+		},
+	})
+	c := NewHttpClient(WithCircuitBreaker(breaker))
+
+	for range 2 {
+		resp, err := c.Get(server.URL, nil, nil)
+		assert.NoError(t, err)
+		assert.True(t, resp.IsServerError())
+	}
+	assert.Equal(t, StateOpen, breaker.State(host))
+
+	_, err := c.Get(server.URL, nil, nil)
+	var openErr *CircuitOpenError
+	assert.True(t, errors.As(err, &openErr))
+	assert.Equal(t, host, openErr.Host)
+
+	time.Sleep(30 * time.Millisecond)
+	healthy = true
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+	assert.Equal(t, StateClosed, breaker.State(host))
+	assert.Equal(t, []BreakerState{StateOpen, StateHalfOpen, StateClosed}, transitions)
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	var transitions []BreakerState
+	breaker := NewCircuitBreaker(BreakerConfig{
+		MinRequests:  2,
+		OpenDuration: 10 * time.Millisecond,
+		OnStateChange: func(host string, from, to BreakerState) {
+			transitions = append(transitions, to)
+		},
+	})
+
+	// admitted while closed, finishes only after the circuit went half-open
+	slow, err := breaker.allow("host")
+	assert.NoError(t, err)
+	for range 2 {
+		generation, _ := breaker.allow("host")
+		breaker.record("host", generation, true)
+	}
+	time.Sleep(20 * time.Millisecond)
+	probe, err := breaker.allow("host")
+	assert.NoError(t, err)
+
+	breaker.record("host", slow, false)
+	assert.Equal(t, StateHalfOpen, breaker.State("host"))
+	_, err = breaker.allow("host")
+	assert.Error(t, err, "the probe is still in flight")
+
+	breaker.record("host", probe, false)
+	assert.Equal(t, StateClosed, breaker.State("host"))
+	assert.Equal(t, []BreakerState{StateOpen, StateHalfOpen, StateClosed}, transitions)
+}
+
+func TestCircuitBreaker_CancelledProbe(t *testing.T) {
+	var transitions []BreakerState
+	breaker := NewCircuitBreaker(BreakerConfig{
+		MinRequests:  1,
+		OpenDuration: 10 * time.Millisecond,
+		OnStateChange: func(host string, from, to BreakerState) {
+			transitions = append(transitions, to)
+		},
+	})
+	interceptor := breaker.Interceptor()
+	req := httptest.NewRequest(http.MethodGet, "http://host/", nil)
+	fail := func(req *http.Request) (*Response, error) { return nil, errors.New("connection refused") }
+	cancelled := func(req *http.Request) (*Response, error) { return nil, context.Canceled }
+
+	_, _ = interceptor(req, fail)
+	time.Sleep(20 * time.Millisecond)
+	_, err := interceptor(req, cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateHalfOpen, breaker.State("host"))
+
+	// the cancelled probe gave its slot back
+	_, err = interceptor(req, fail)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, []BreakerState{StateOpen, StateHalfOpen, StateOpen}, transitions)
+}
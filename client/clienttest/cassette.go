@@ -0,0 +1,90 @@
+package clienttest
+
+import (
+	"github.com/goccy/go-json"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Cassette is a recorded list of HTTP interactions. It is stored as YAML when the
+// file name ends with .yaml or .yml and as JSON otherwise.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions" yaml:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request" yaml:"request"`
+	Response RecordedResponse `json:"response" yaml:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method" yaml:"method"`
+	URL    string      `json:"url" yaml:"url"`
+	Header http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body   Body        `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode" yaml:"statusCode"`
+	Header     http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       Body        `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Body is a recorded body. It is base64 in JSON; YAML keeps text readable and
+// only uses !!binary for bodies that are not valid UTF-8.
+type Body []byte
+
+func (b Body) MarshalYAML() (interface{}, error) {
+	return string(b), nil
+}
+
+func (b *Body) UnmarshalYAML(node *yaml.Node) error {
+	var text string
+	if err := node.Decode(&text); err != nil {
+		return err
+	}
+	*b = Body(text)
+	return nil
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if isYAML(path) {
+		err = yaml.Unmarshal(data, &cassette)
+	} else {
+		err = json.Unmarshal(data, &cassette)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cassette, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	var data []byte
+	var err error
+	if isYAML(path) {
+		data, err = yaml.Marshal(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
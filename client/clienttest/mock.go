@@ -0,0 +1,152 @@
+package clienttest
+
+import (
+	"fmt"
+	"github.com/goccy/go-json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// Mock is an http.RoundTripper answering with responses declared inline.
+// Install it with client.WithTransport and finish with AssertExpectations.
+type Mock struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	unexpected   []string
+}
+
+// Expectation is a declared request and the response it gets.
+type Expectation struct {
+	request  RecordedRequest
+	matchers []Matcher
+	response RecordedResponse
+	times    int // expected calls, 0 means at least once
+	calls    int
+}
+
+func NewMock() *Mock {
+	return &Mock{}
+}
+
+// On declares a request by method and full URL, query included.
+func (m *Mock) On(method, url string) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &Expectation{
+		request:  RecordedRequest{Method: method, URL: url},
+		matchers: []Matcher{MatchMethod, MatchURL},
+		response: RecordedResponse{StatusCode: http.StatusOK},
+	}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Match adds conditions on top of method and URL.
+func (e *Expectation) Match(matchers ...Matcher) *Expectation {
+	e.matchers = append(e.matchers, matchers...)
+	return e
+}
+
+// ExpectHeader only matches requests carrying the header value.
+func (e *Expectation) ExpectHeader(key, value string) *Expectation {
+	if e.request.Header == nil {
+		e.request.Header = make(http.Header)
+	}
+	e.request.Header.Add(key, value)
+	return e.Match(MatchHeaders(key))
+}
+
+// ExpectBody only matches requests with exactly this body.
+func (e *Expectation) ExpectBody(body string) *Expectation {
+	e.request.Body = Body(body)
+	return e.Match(MatchBody)
+}
+
+func (e *Expectation) Reply(statusCode int, body string) *Expectation {
+	e.response.StatusCode = statusCode
+	e.response.Body = Body(body)
+	return e
+}
+
+// ReplyJSON replies with v encoded as JSON; it panics when v cannot be encoded.
+func (e *Expectation) ReplyJSON(statusCode int, v interface{}) *Expectation {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return e.Reply(statusCode, string(body)).WithHeader("Content-Type", "application/json")
+}
+
+func (e *Expectation) WithHeader(key, value string) *Expectation {
+	if e.response.Header == nil {
+		e.response.Header = make(http.Header)
+	}
+	e.response.Header.Add(key, value)
+	return e
+}
+
+// Times expects exactly n calls.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+func (m *Mock) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.expectations {
+		if e.matches(req, body) {
+			e.calls++
+			return newResponse(req, e.response), nil
+		}
+	}
+	call := fmt.Sprintf("%s %s", req.Method, req.URL)
+	m.unexpected = append(m.unexpected, call)
+	return nil, fmt.Errorf("clienttest: unexpected request %s", call)
+}
+
+// AssertExpectations fails t for every expectation called the wrong number of
+// times and for every request that matched none.
+func (m *Mock) AssertExpectations(t testing.TB) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, e := range m.expectations {
+		if (e.times == 0 && e.calls == 0) || (e.times > 0 && e.calls != e.times) {
+			t.Errorf("expected %s %s to be called %s, got %d calls", e.request.Method, e.request.URL, e.expectedCalls(), e.calls)
+			ok = false
+		}
+	}
+	for _, call := range m.unexpected {
+		t.Errorf("unexpected request %s", call)
+		ok = false
+	}
+	return ok
+}
+
+func (e *Expectation) matches(req *http.Request, body []byte) bool {
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+	for _, matcher := range e.matchers {
+		if !matcher(req, body, e.request) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Expectation) expectedCalls() string {
+	if e.times == 0 {
+		return "at least once"
+	}
+	return fmt.Sprintf("%d times", e.times)
+}
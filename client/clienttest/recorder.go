@@ -0,0 +1,230 @@
+package clienttest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/huhx/common-go/client"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+const maskedValue = "***"
+
+type Mode int
+
+const (
+	// ModeRecord sends requests to the real upstream and records them.
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the cassette without any network access.
+	ModeReplay
+)
+
+// Matcher reports whether a request matches a recorded one; body is the request body.
+type Matcher func(req *http.Request, body []byte, recorded RecordedRequest) bool
+
+func MatchMethod(req *http.Request, _ []byte, recorded RecordedRequest) bool {
+	return req.Method == recorded.Method
+}
+
+func MatchURL(req *http.Request, _ []byte, recorded RecordedRequest) bool {
+	return req.URL.String() == recorded.URL
+}
+
+func MatchBody(_ *http.Request, body []byte, recorded RecordedRequest) bool {
+	return bytes.Equal(body, recorded.Body)
+}
+
+// MatchHeaders compares the given request headers.
+func MatchHeaders(names ...string) Matcher {
+	return func(req *http.Request, _ []byte, recorded RecordedRequest) bool {
+		for _, name := range names {
+			if !slices.Equal(req.Header.Values(name), recorded.Header.Values(name)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette file
+// or replays them from it. Install it with client.WithTransport.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	matchers  []Matcher
+	masked    []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+type RecorderOption func(*Recorder)
+
+// WithMatchers replaces the default method and URL matching used in replay mode.
+func WithMatchers(matchers ...Matcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// WithRealTransport sets the transport used in record mode, http.DefaultTransport by default.
+func WithRealTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMaskedHeaders masks the given headers in recorded requests and responses,
+// on top of the credential headers listed by client.SensitiveHeaders. Masked
+// headers cannot be matched with MatchHeaders on replay.
+func WithMaskedHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.masked = append(r.masked, names...)
+	}
+}
+
+// NewRecorder records into path in ModeRecord and loads path in ModeReplay.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+		matchers:  []Matcher{MatchMethod, MatchURL},
+		masked:    client.SensitiveHeaders(),
+		cassette:  &Cassette{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if mode == ModeReplay {
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	}
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// Stop writes the cassette in record mode; it does nothing in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.mask(req.Header),
+			Body:   body,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.mask(resp.Header),
+			Body:       respBody,
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// replay answers with the first unused interaction that satisfies every matcher.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matches(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		return newResponse(req, interaction.Response), nil
+	}
+	return nil, fmt.Errorf("clienttest: no recorded interaction matches %s %s", req.Method, req.URL)
+}
+
+// mask copies header for the cassette, hiding the values of credential headers.
+func (r *Recorder) mask(header http.Header) http.Header {
+	masked := header.Clone()
+	for _, name := range r.masked {
+		if masked.Get(name) != "" {
+			masked.Set(name, maskedValue)
+		}
+	}
+	return masked
+}
+
+func (r *Recorder) matches(req *http.Request, body []byte, recorded RecordedRequest) bool {
+	for _, matcher := range r.matchers {
+		if !matcher(req, body, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newResponse(req *http.Request, recorded RecordedResponse) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
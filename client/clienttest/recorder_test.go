@@ -0,0 +1,107 @@
+package clienttest
+
+import (
+	"github.com/huhx/common-go/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("hello " + r.URL.Query().Get("name")))
+			}))
+			path := filepath.Join(t.TempDir(), name)
+
+			recorder, err := NewRecorder(path, ModeRecord)
+			assert.NoError(t, err)
+			c := client.NewHttpClient(client.WithTransport(recorder))
+			resp, err := c.Get(server.URL, map[string]string{"name": "huhx"}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello huhx", resp.StringBody())
+			assert.NoError(t, recorder.Stop())
+			server.Close()
+
+			replayer, err := NewRecorder(path, ModeReplay)
+			assert.NoError(t, err)
+			c = client.NewHttpClient(client.WithTransport(replayer))
+			resp, err = c.Get(server.URL, map[string]string{"name": "huhx"}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello huhx", resp.StringBody())
+
+			_, err = c.Get(server.URL, map[string]string{"name": "huhx"}, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRecorder_BinaryBody(t *testing.T) {
+	binary := []byte{0xff, 0xd8, 0x00, 'j', 'p', 'g'}
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(binary)
+			}))
+			path := filepath.Join(t.TempDir(), name)
+
+			recorder, err := NewRecorder(path, ModeRecord)
+			assert.NoError(t, err)
+			c := client.NewHttpClient(client.WithTransport(recorder))
+			_, err = c.Get(server.URL, nil, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, recorder.Stop())
+			server.Close()
+
+			replayer, err := NewRecorder(path, ModeReplay)
+			assert.NoError(t, err)
+			c = client.NewHttpClient(client.WithTransport(replayer))
+			resp, err := c.Get(server.URL, nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, binary, resp.Body)
+		})
+	}
+}
+
+func TestRecorder_MasksHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	recorder, err := NewRecorder(path, ModeRecord, WithMaskedHeaders("X-Api-Key"))
+	assert.NoError(t, err)
+	c := client.NewHttpClient(client.WithTransport(recorder))
+	_, err = c.Get(server.URL, nil, http.Header{"Authorization": {"Bearer token"}, "X-Api-Key": {"key"}, "X-Trace-Id": {"trace"}})
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Stop())
+
+	cassette, err := LoadCassette(path)
+	assert.NoError(t, err)
+	interaction := cassette.Interactions[0]
+	assert.Equal(t, "***", interaction.Request.Header.Get("Authorization"))
+	assert.Equal(t, "***", interaction.Request.Header.Get("X-Api-Key"))
+	assert.Equal(t, "trace", interaction.Request.Header.Get("X-Trace-Id"))
+	assert.Equal(t, "***", interaction.Response.Header.Get("Set-Cookie"))
+}
+
+func TestMock(t *testing.T) {
+	mock := NewMock()
+	mock.On(http.MethodGet, "https://api.example.com/users/1").ReplyJSON(http.StatusOK, map[string]string{"name": "huhx"})
+	mock.On(http.MethodPost, "https://api.example.com/users").ExpectBody(`{"name":"huhx"}`).Reply(http.StatusCreated, "").Times(1)
+
+	c := client.NewHttpClient(client.WithTransport(mock), client.WithBaseURL("https://api.example.com"))
+	resp, err := c.Get("/users/1", nil, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"huhx"}`, resp.StringBody())
+
+	resp, err = c.Post("/users", map[string]string{"name": "huhx"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	assert.True(t, mock.AssertExpectations(t))
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"mime"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupportedContentType = errors.New("no codec registered for content type")
+
+// Codec encodes request payloads and decodes response bodies of one content type.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var codecs sync.Map
+
+func init() {
+	RegisterCodec(contentTypeJSON, JSONCodec{})
+	RegisterCodec("application/xml", XMLCodec{})
+	RegisterCodec("text/xml", XMLCodec{})
+	RegisterCodec(contentTypeForm, FormCodec{})
+	RegisterCodec("text/plain", TextCodec{})
+	RegisterCodec("application/x-protobuf", ProtoCodec{})
+	RegisterCodec("application/protobuf", ProtoCodec{})
+}
+
+// RegisterCodec makes codec handle contentType, replacing any codec registered before.
+func RegisterCodec(contentType string, codec Codec) {
+	codecs.Store(mediaType(contentType), codec)
+}
+
+// CodecFor looks up the codec of contentType; parameters such as charset are
+// ignored and structured suffixes like application/problem+json fall back to
+// the json and xml codecs.
+func CodecFor(contentType string) (Codec, bool) {
+	media := mediaType(contentType)
+	if codec, ok := codecs.Load(media); ok {
+		return codec.(Codec), true
+	}
+	switch {
+	case strings.HasSuffix(media, "+json"):
+		return CodecFor(contentTypeJSON)
+	case strings.HasSuffix(media, "+xml"):
+		return CodecFor("application/xml")
+	}
+	return nil, false
+}
+
+// Decode decodes the response body with the codec matching its Content-Type,
+// treating a response without one as JSON.
+func Decode[R any](response *Response) (*R, error) {
+	contentType := response.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+	codec, ok := CodecFor(contentType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	var result R
+	if err := codec.Unmarshal(response.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func mediaType(contentType string) string {
+	if media, _, err := mime.ParseMediaType(contentType); err == nil {
+		return media
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type XMLCodec struct{}
+
+func (XMLCodec) Marshal(v interface{}) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+func (XMLCodec) Unmarshal(data []byte, v interface{}) error {
+	return xml.Unmarshal(data, v)
+}
+
+// FormCodec handles url.Values, map[string]string and map[string][]string.
+type FormCodec struct{}
+
+func (FormCodec) Marshal(v interface{}) ([]byte, error) {
+	switch form := v.(type) {
+	case url.Values:
+		return []byte(form.Encode()), nil
+	case map[string][]string:
+		return []byte(url.Values(form).Encode()), nil
+	case map[string]string:
+		values := make(url.Values, len(form))
+		for key, value := range form {
+			values.Set(key, value)
+		}
+		return []byte(values.Encode()), nil
+	default:
+		return nil, fmt.Errorf("cannot encode %T as form", v)
+	}
+}
+
+func (FormCodec) Unmarshal(data []byte, v interface{}) error {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	switch form := v.(type) {
+	case *url.Values:
+		*form = values
+	case *map[string][]string:
+		*form = values
+	case *map[string]string:
+		*form = make(map[string]string, len(values))
+		for key := range values {
+			(*form)[key] = values.Get(key)
+		}
+	default:
+		return fmt.Errorf("cannot decode form into %T", v)
+	}
+	return nil
+}
+
+// TextCodec handles string, []byte and fmt.Stringer values.
+type TextCodec struct{}
+
+func (TextCodec) Marshal(v interface{}) ([]byte, error) {
+	switch text := v.(type) {
+	case string:
+		return []byte(text), nil
+	case []byte:
+		return text, nil
+	case fmt.Stringer:
+		return []byte(text.String()), nil
+	default:
+		return nil, fmt.Errorf("cannot encode %T as text", v)
+	}
+}
+
+func (TextCodec) Unmarshal(data []byte, v interface{}) error {
+	switch text := v.(type) {
+	case *string:
+		*text = string(data)
+	case *[]byte:
+		*text = append((*text)[:0], data...)
+	default:
+		return fmt.Errorf("cannot decode text into %T", v)
+	}
+	return nil
+}
+
+// ProtoCodec handles messages that marshal themselves, such as gogo/protobuf
+// messages. For google.golang.org/protobuf register a codec wrapping
+// proto.Marshal and proto.Unmarshal instead.
+type ProtoCodec struct{}
+
+func (ProtoCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(interface{ Marshal() ([]byte, error) })
+	if !ok {
+		return nil, fmt.Errorf("cannot encode %T as protobuf, it has no Marshal method", v)
+	}
+	return message.Marshal()
+}
+
+func (ProtoCodec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(interface{ Unmarshal([]byte) error })
+	if !ok {
+		return fmt.Errorf("cannot decode protobuf into %T, it has no Unmarshal method", v)
+	}
+	return message.Unmarshal(data)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type upperCodec struct{ TextCodec }
+
+func (c upperCodec) Unmarshal(data []byte, v interface{}) error {
+	return c.TextCodec.Unmarshal([]byte("UPPER:"+string(data)), v)
+}
+
+func TestCodecFor(t *testing.T) {
+	codec, ok := CodecFor("application/problem+json; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, JSONCodec{}, codec)
+
+	_, ok = CodecFor("application/msgpack")
+	assert.False(t, ok)
+}
+
+func TestHttpClient_Codecs(t *testing.T) {
+	RegisterCodec("text/x-upper", upperCodec{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	resp, err := c.Post(server.URL, map[string]string{"name": "huhx"}, http.Header{
+		"Content-Type": {"application/x-www-form-urlencoded"},
+		"Accept":       {"application/x-www-form-urlencoded"},
+	})
+	assert.NoError(t, err)
+	form, err := Decode[map[string]string](resp)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "huhx"}, *form)
+
+	resp, err = c.Post(server.URL, "hello", http.Header{"Content-Type": {"text/plain"}, "Accept": {"text/x-upper"}})
+	assert.NoError(t, err)
+	text, err := Decode[string](resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPPER:hello", *text)
+
+	resp, err = c.Post(server.URL, "hello", http.Header{"Accept": {"application/msgpack"}})
+	assert.NoError(t, err)
+	_, err = Decode[string](resp)
+	assert.ErrorIs(t, err, ErrUnsupportedContentType)
+
+	_, err = c.Post(server.URL, map[string]int{"a": 1}, http.Header{"Content-Type": {"application/msgpack"}})
+	assert.ErrorIs(t, err, ErrUnsupportedContentType)
+}
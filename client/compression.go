@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedEncoding is returned for a content encoding without a registered decoder.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// Decoder wraps a compressed body with a reader of the decompressed bytes.
+type Decoder func(r io.Reader) (io.ReadCloser, error)
+
+var decoders sync.Map
+
+func init() {
+	RegisterDecoder("gzip", func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	})
+	RegisterDecoder("deflate", decodeDeflate)
+	RegisterDecoder("br", func(r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(brotli.NewReader(r)), nil
+	})
+}
+
+// RegisterDecoder adds support for a Content-Encoding, replacing the decoder
+// registered before; gzip, deflate and br are supported out of the box.
+func RegisterDecoder(encoding string, decoder Decoder) {
+	decoders.Store(strings.ToLower(encoding), decoder)
+}
+
+// WithRequestCompression gzips request bodies of at least minSize bytes and
+// sets Content-Encoding accordingly. Streamed bodies have no known size and are
+// always compressed.
+func WithRequestCompression(minSize int) Option {
+	return func(c *HttpClient) {
+		c.compressMinSize = max(minSize, 1)
+	}
+}
+
+// WithAcceptEncoding advertises the given encodings and decodes responses using
+// them. Every encoding needs a registered decoder, otherwise requests fail with
+// ErrUnsupportedEncoding. The body size limit applies to the decoded bytes.
+func WithAcceptEncoding(encodings ...string) Option {
+	return func(c *HttpClient) {
+		c.acceptEncoding = encodings
+	}
+}
+
+func (c *HttpClient) acceptEncodingHeader() (string, error) {
+	for _, encoding := range c.acceptEncoding {
+		if _, ok := decoders.Load(strings.ToLower(encoding)); !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+		}
+	}
+	return strings.Join(c.acceptEncoding, ", "), nil
+}
+
+func (c *HttpClient) compressBody(body []byte, header http.Header) ([]byte, error) {
+	if c.compressMinSize == 0 || len(body) < c.compressMinSize || header.Get("Content-Encoding") != "" {
+		return body, nil
+	}
+
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	header.Set("Content-Encoding", "gzip")
+	return buffer.Bytes(), nil
+}
+
+func (c *HttpClient) compressStream(body io.Reader, header http.Header) io.ReadCloser {
+	if c.compressMinSize == 0 || header.Get("Content-Encoding") != "" {
+		if closer, ok := body.(io.ReadCloser); ok {
+			return closer
+		}
+		return io.NopCloser(body)
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	return newPipeBody(func(w io.Writer) error {
+		writer := gzip.NewWriter(w)
+		if _, err := io.Copy(writer, body); err != nil {
+			return err
+		}
+		return writer.Close()
+	})
+}
+
+// decodeBody undoes the Content-Encoding of resp, last applied encoding first,
+// and drops the headers that describe the encoded body.
+func (c *HttpClient) decodeBody(resp *http.Response) (io.ReadCloser, error) {
+	contentEncoding := resp.Header.Get("Content-Encoding")
+	if len(c.acceptEncoding) == 0 || contentEncoding == "" {
+		return resp.Body, nil
+	}
+
+	encodings := strings.Split(contentEncoding, ",")
+	closers := []io.Closer{resp.Body}
+	var reader io.Reader = resp.Body
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if encoding == "identity" {
+			continue
+		}
+		decoder, ok := decoders.Load(encoding)
+		if !ok {
+			resp.Body.Close()
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+		}
+		decoded, err := decoder.(Decoder)(reader)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		closers = append(closers, decoded)
+		reader = decoded
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return &decodedBody{Reader: reader, closers: closers}, nil
+}
+
+type decodedBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	var err error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if closeErr := b.closers[i].Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// decodeDeflate accepts both zlib wrapped deflate, as the spec says, and the raw
+// deflate some servers send instead.
+func decodeDeflate(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(2)
+	if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
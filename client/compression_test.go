@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHttpClient_Compression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			assert.NoError(t, err)
+			body, _ = io.ReadAll(reader)
+		} else {
+			body, _ = io.ReadAll(r.Body)
+		}
+
+		if r.URL.Path == "/bomb" {
+			body = make([]byte, 2*limitReadSize)
+		}
+		var buffer bytes.Buffer
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "br") && r.URL.Path == "/br" {
+			w.Header().Set("Content-Encoding", "br")
+			writer := brotli.NewWriter(&buffer)
+			_, _ = writer.Write(body)
+			_ = writer.Close()
+		} else if strings.Contains(r.Header.Get("Accept-Encoding"), "deflate") && r.URL.Path == "/deflate" {
+			w.Header().Set("Content-Encoding", "deflate")
+			writer := zlib.NewWriter(&buffer)
+			_, _ = writer.Write(body)
+			_ = writer.Close()
+		} else {
+			w.Header().Set("Content-Encoding", "gzip")
+			writer := gzip.NewWriter(&buffer)
+			_, _ = writer.Write(body)
+			_ = writer.Close()
+		}
+		_, _ = w.Write(buffer.Bytes())
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithRequestCompression(16), WithAcceptEncoding("br", "gzip", "deflate"))
+	payload := strings.Repeat("a", 64)
+
+	resp, err := c.Post(server.URL, payload, http.Header{"Content-Type": {"text/plain"}})
+	assert.NoError(t, err)
+	assert.Equal(t, payload, resp.StringBody())
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	resp, err = c.Post(server.URL+"/deflate", payload, http.Header{"Content-Type": {"text/plain"}})
+	assert.NoError(t, err)
+	assert.Equal(t, payload, resp.StringBody())
+
+	resp, err = c.Post(server.URL+"/br", payload, http.Header{"Content-Type": {"text/plain"}})
+	assert.NoError(t, err)
+	assert.Equal(t, payload, resp.StringBody())
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	_, err = c.Get(server.URL+"/bomb", nil, nil)
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestHttpClient_AcceptEncodingWithoutDecoder(t *testing.T) {
+	c := NewHttpClient(WithAcceptEncoding("zstd", "gzip"))
+
+	_, err := c.Get("http://localhost", nil, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+	assert.ErrorContains(t, err, "zstd")
+}
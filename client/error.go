@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/huhx/common-go/exception"
+	"net/http"
+)
+
+const errorBodySize = 4 * 1024 // 4K
+
+// HTTPError describes a non-2xx response. Body holds at most the first 4K of the
+// response body, so the error stays cheap to keep around and log.
+type HTTPError struct {
+	Status     string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func NewHTTPError(response *Response) *HTTPError {
+	body := response.Body
+	if len(body) > errorBodySize {
+		body = body[:errorBodySize]
+	}
+	return &HTTPError{
+		Status:     response.Status,
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+	}
+}
+
+func (e *HTTPError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("unexpected response status: %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected response status: %s: %s", e.Status, e.Body)
+}
+
+func (e *HTTPError) Code() int {
+	return e.StatusCode
+}
+
+// Message returns the message of a {"message": ...} body when the upstream sent
+// one, otherwise the status text.
+func (e *HTTPError) Message() string {
+	if message := e.bodyMessage(); message != "" {
+		return message
+	}
+	return http.StatusText(e.StatusCode)
+}
+
+// Exception maps the error to the matching exception kind so it can be returned
+// through the API layer as is. Statuses without a dedicated kind map to e itself.
+func (e *HTTPError) Exception() exception.Exception {
+	content := e.bodyMessage()
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return exception.BadRequest{Content: content}
+	case e.StatusCode == http.StatusUnauthorized:
+		return exception.Unauthenticated{Content: content}
+	case e.StatusCode == http.StatusForbidden:
+		return exception.Unauthorized{Content: content}
+	case e.StatusCode == http.StatusNotFound:
+		return exception.NotFound{Content: content}
+	case e.StatusCode >= 500 && e.StatusCode <= 599:
+		return exception.System{Content: content}
+	default:
+		return e
+	}
+}
+
+func (e *HTTPError) bodyMessage() string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(e.Body, &body); err != nil {
+		return ""
+	}
+	return body.Message
+}
+
+// ResultError is returned when a base.Result envelope carries a non-zero code.
+type ResultError struct {
+	ResultCode    int
+	ResultMessage string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("result code %d: %s", e.ResultCode, e.ResultMessage)
+}
+
+func (e *ResultError) Code() int {
+	return e.ResultCode
+}
+
+func (e *ResultError) Message() string {
+	return e.ResultMessage
+}
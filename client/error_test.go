@@ -0,0 +1,59 @@
+package client
+
+import (
+	"github.com/huhx/common-go/exception"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHTTPError_Exception(t *testing.T) {
+	tests := []struct {
+		name     string
+		response Response
+		want     exception.Exception
+	}{
+		{
+			name:     "bad request with message",
+			response: Response{StatusCode: 400, Body: []byte(`{"message":"name is required"}`)},
+			want:     exception.BadRequest{Content: "name is required"},
+		},
+		{
+			name:     "unauthenticated",
+			response: Response{StatusCode: 401},
+			want:     exception.Unauthenticated{},
+		},
+		{
+			name:     "unauthorized",
+			response: Response{StatusCode: 403},
+			want:     exception.Unauthorized{},
+		},
+		{
+			name:     "not found with plain body",
+			response: Response{StatusCode: 404, Body: []byte("no such user")},
+			want:     exception.NotFound{},
+		},
+		{
+			name:     "server error",
+			response: Response{StatusCode: 503},
+			want:     exception.System{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.response.Err().(*HTTPError).Exception())
+		})
+	}
+}
+
+func TestHTTPError_TruncatesBody(t *testing.T) {
+	response := Response{Status: "409 Conflict", StatusCode: http.StatusConflict, Body: []byte(strings.Repeat("x", 10*errorBodySize))}
+	err := NewHTTPError(&response)
+
+	assert.Len(t, err.Body, errorBodySize)
+	assert.Equal(t, err, err.Exception())
+	assert.Equal(t, "Conflict", err.Message())
+	assert.Nil(t, (&Response{StatusCode: http.StatusOK}).Err())
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/goccy/go-json"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultEventRetry = 3 * time.Second
+
+// Event is one Server-Sent Event.
+type Event struct {
+	ID    string
+	Event string // event type, empty means "message"
+	Data  string
+	Retry time.Duration
+}
+
+// Events consumes a Server-Sent Events stream. When the connection drops it
+// reconnects after the server's retry delay (3s by default), sending
+// Last-Event-ID. Connection errors are yielded before reconnecting; a non-2xx
+// response is yielded as *HTTPError and ends the stream, as does 204 No Content.
+func (c *HttpClient) Events(ctx context.Context, urlPath string, header http.Header, opts ...RequestOption) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		var lastEventID string
+		retry := defaultEventRetry
+
+		for {
+			reqHeader := header.Clone()
+			if reqHeader == nil {
+				reqHeader = make(http.Header)
+			}
+			reqHeader.Set("Accept", "text/event-stream")
+			reqHeader.Set("Cache-Control", "no-cache")
+			if lastEventID != "" {
+				reqHeader.Set("Last-Event-ID", lastEventID)
+			}
+
+			resp, err := c.Stream(ctx, http.MethodGet, urlPath, nil, reqHeader, opts...)
+			switch {
+			case ctx.Err() != nil:
+				if resp != nil {
+					resp.Body.Close()
+				}
+				yield(Event{}, ctx.Err())
+				return
+			case err != nil:
+				if !yield(Event{}, err) {
+					return
+				}
+			case resp.StatusCode == http.StatusNoContent:
+				resp.Body.Close()
+				return
+			case !resp.IsSuccess():
+				err := resp.Err()
+				resp.Body.Close()
+				yield(Event{}, err)
+				return
+			default:
+				stopped := false
+				err := readEvents(resp.Body, lastEventID, func(event Event) bool {
+					lastEventID = event.ID
+					if event.Retry > 0 {
+						retry = event.Retry
+					}
+					stopped = !yield(event, nil)
+					return !stopped
+				})
+				resp.Body.Close()
+				if stopped {
+					return
+				}
+				if ctx.Err() != nil {
+					yield(Event{}, ctx.Err())
+					return
+				}
+				if err != nil && !yield(Event{}, err) {
+					return
+				}
+			}
+
+			if err := sleep(ctx, retry); err != nil {
+				yield(Event{}, err)
+				return
+			}
+		}
+	}
+}
+
+// readEvents parses the stream until it ends or emit returns false and returns
+// the read error that ended it, nil at the end of the body. The id field persists
+// across events, so the first event starts with lastEventID.
+func readEvents(r io.Reader, lastEventID string, emit func(Event) bool) error {
+	reader := bufio.NewReader(r)
+	event := Event{ID: lastEventID}
+	var data strings.Builder
+	hasData := false
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return ignoreEOF(err)
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if hasData {
+				event.Data = data.String()
+				if !emit(event) {
+					return nil
+				}
+			}
+			event = Event{ID: event.ID}
+			data.Reset()
+			hasData = false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				event.ID = value
+			}
+		case "retry":
+			if millis, err := strconv.Atoi(value); err == nil && millis >= 0 {
+				event.Retry = time.Duration(millis) * time.Millisecond
+			}
+		}
+		if err != nil {
+			return ignoreEOF(err)
+		}
+	}
+}
+
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// NDJSON streams a newline-delimited JSON response, decoding one T per line.
+// A non-2xx response is yielded as *HTTPError; any error ends the iteration.
+func NDJSON[T any](ctx context.Context, c *HttpClient, method, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		reqHeader := header.Clone()
+		if reqHeader == nil {
+			reqHeader = make(http.Header)
+		}
+		if reqHeader.Get("Accept") == "" {
+			reqHeader.Set("Accept", "application/x-ndjson")
+		}
+
+		resp, err := c.Stream(ctx, method, urlPath, payload, reqHeader, opts...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer resp.Body.Close()
+		if err := resp.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		reader := bufio.NewReader(resp.Body)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				var value T
+				if decodeErr := json.Unmarshal(line, &value); decodeErr != nil {
+					yield(zero, decodeErr)
+					return
+				}
+				if !yield(value, nil) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
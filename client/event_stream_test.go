@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_Events(t *testing.T) {
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		if r.Header.Get("Last-Event-ID") == "" {
+			_, _ = w.Write([]byte(": comment\nretry: 10\nid: 1\nevent: created\ndata: first\ndata: line\n\n"))
+			return
+		}
+		_, _ = w.Write([]byte("id: 2\ndata: second\r\n\r\n"))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	var events []Event
+	for event, err := range c.Events(context.Background(), server.URL, nil) {
+		assert.NoError(t, err)
+		events = append(events, event)
+		if len(events) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []Event{
+		{ID: "1", Event: "created", Data: "first\nline", Retry: 10 * time.Millisecond},
+		{ID: "2", Data: "second"},
+	}, events)
+	assert.Equal(t, []string{"", "1"}, lastEventIDs)
+}
+
+func TestHttpClient_EventsReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("retry: 10\ndata: first\n\ndata: " + strings.Repeat("a", 64) + "\n\n"))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	var events []Event
+	var errs []error
+	for event, err := range c.Events(context.Background(), server.URL, nil, WithMaxBodySize(32)) {
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		events = append(events, event)
+	}
+
+	assert.Equal(t, []Event{{Data: "first", Retry: 10 * time.Millisecond}}, events)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrBodyTooLarge)
+}
+
+type closeRecorder struct {
+	io.ReadCloser
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return r.ReadCloser.Close()
+}
+
+func TestHttpClient_EventsCancelledClosesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var body *closeRecorder
+	// the context is cancelled right after the response headers arrive
+	c := NewHttpClient(WithInterceptors(func(req *http.Request, next Handler) (*Response, error) {
+		resp, err := next(req)
+		if err == nil {
+			body = &closeRecorder{ReadCloser: resp.stream}
+			resp.stream = body
+		}
+		cancel()
+		return resp, err
+	}))
+	for _, err := range c.Events(ctx, server.URL, nil) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	assert.True(t, body.closed)
+}
+
+func TestNDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("{\"name\":\"a\"}\n\n{\"name\":\"b\"}\n{\"name\":\"c\"}"))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	var names []string
+	for item, err := range NDJSON[user](context.Background(), &c, http.MethodGet, server.URL, nil, nil) {
+		assert.NoError(t, err)
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	for _, err := range NDJSON[user](context.Background(), &c, http.MethodGet, server.URL+"/missing", nil, nil) {
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+	}
+}
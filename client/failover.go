@@ -0,0 +1,168 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FailoverConfig configures a Failover; zero fields take the defaults noted below.
+type FailoverConfig struct {
+	MaxFailures int           // consecutive failures that eject an endpoint, default 3
+	EjectFor    time.Duration // how long an ejected endpoint is skipped, default 30s
+}
+
+// Failover spreads requests round-robin over replicated base URLs and retries an
+// idempotent request that fails with an error or 5xx on the next endpoint.
+// Endpoints that keep failing are ejected for a while; when all of them are
+// ejected they are tried anyway.
+type Failover struct {
+	config    FailoverConfig
+	endpoints []*endpoint
+	next      atomic.Uint64
+}
+
+type endpoint struct {
+	url          *url.URL
+	mu           sync.Mutex
+	failures     int
+	ejectedUntil time.Time
+}
+
+func NewFailover(baseURLs []string, config FailoverConfig) (*Failover, error) {
+	if len(baseURLs) == 0 {
+		return nil, errors.New("failover needs at least one base url")
+	}
+	if config.MaxFailures <= 0 {
+		config.MaxFailures = 3
+	}
+	if config.EjectFor <= 0 {
+		config.EjectFor = 30 * time.Second
+	}
+
+	f := &Failover{config: config}
+	for _, baseURL := range baseURLs {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		f.endpoints = append(f.endpoints, &endpoint{url: u})
+	}
+	return f, nil
+}
+
+// WithFailover routes requests through failover. Unless a base URL is set, the
+// first endpoint becomes the base URL so relative paths can be used.
+func WithFailover(failover *Failover) Option {
+	return func(c *HttpClient) {
+		if c.baseURL == "" {
+			c.baseURL = failover.endpoints[0].url.String()
+		}
+		c.Use(failover.Interceptor())
+	}
+}
+
+// Interceptor rewrites requests aimed at any of the endpoints; other requests pass through.
+func (f *Failover) Interceptor() Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		path, ok := f.relativePath(req.URL)
+		if !ok {
+			return next(req)
+		}
+
+		candidates := f.candidates()
+		if !isIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			candidates = candidates[:1]
+		}
+
+		var resp *Response
+		var err error
+		for i, target := range candidates {
+			attempt := req.Clone(req.Context())
+			if i > 0 && req.GetBody != nil {
+				if attempt.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			attempt.URL = target.join(path, req.URL)
+			attempt.Host = attempt.URL.Host
+
+			resp, err = next(attempt)
+			if req.Context().Err() != nil {
+				return resp, err
+			}
+			failed := err != nil || resp.IsServerError()
+			target.record(failed, f.config)
+			if !failed {
+				return resp, nil
+			}
+			if i < len(candidates)-1 {
+				resp.close()
+			}
+		}
+		return resp, err
+	}
+}
+
+// candidates lists every endpoint once, starting at the round-robin position,
+// healthy endpoints first.
+func (f *Failover) candidates() []*endpoint {
+	start := int(f.next.Add(1)-1) % len(f.endpoints)
+	now := time.Now()
+	var healthy, ejected []*endpoint
+	for i := range f.endpoints {
+		e := f.endpoints[(start+i)%len(f.endpoints)]
+		if e.isEjected(now) {
+			ejected = append(ejected, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+	return append(healthy, ejected...)
+}
+
+func (f *Failover) relativePath(u *url.URL) (string, bool) {
+	for _, e := range f.endpoints {
+		if u.Scheme != e.url.Scheme || u.Host != e.url.Host {
+			continue
+		}
+		if path, ok := strings.CutPrefix(u.Path, e.url.Path); ok && (path == "" || path[0] == '/') {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func (e *endpoint) join(path string, original *url.URL) *url.URL {
+	u := *original
+	u.Scheme = e.url.Scheme
+	u.Host = e.url.Host
+	u.Path = e.url.Path + path
+	u.RawPath = ""
+	return &u
+}
+
+func (e *endpoint) isEjected(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return now.Before(e.ejectedUntil)
+}
+
+func (e *endpoint) record(failed bool, config FailoverConfig) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !failed {
+		e.failures = 0
+		return
+	}
+	e.failures++
+	if e.failures >= config.MaxFailures {
+		e.failures = 0
+		e.ejectedUntil = time.Now().Add(config.EjectFor)
+	}
+}
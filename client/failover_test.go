@@ -0,0 +1,38 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_Failover(t *testing.T) {
+	var brokenCalls atomic.Int32
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		brokenCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer healthy.Close()
+
+	failover, err := NewFailover([]string{broken.URL + "/api", healthy.URL + "/api"}, FailoverConfig{MaxFailures: 1, EjectFor: time.Minute})
+	assert.NoError(t, err)
+	c := NewHttpClient(WithFailover(failover))
+
+	for range 4 {
+		resp, err := c.Get("/users", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "/api/users", resp.StringBody())
+	}
+	assert.Equal(t, int32(1), brokenCalls.Load())
+
+	resp, err := c.Post("/users", map[string]string{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type hedgeResult struct {
+	resp *Response
+	err  error
+}
+
+// WithHedging sends up to maxHedges duplicates of an idempotent request, one
+// every delay (typically the upstream's p95 latency) while no answer has
+// arrived, and returns the first successful response, cancelling the others.
+// When every request fails the last failure is returned.
+func WithHedging(delay time.Duration, maxHedges int) Option {
+	return WithInterceptors(HedgingInterceptor(delay, maxHedges))
+}
+
+func HedgingInterceptor(delay time.Duration, maxHedges int) Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		if maxHedges <= 0 || !isIdempotent(req) || requestOptionsFrom(req.Context()).stream ||
+			(req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return next(req)
+		}
+
+		// responses are buffered, so every request can be cancelled once one has won
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		results := make(chan hedgeResult, maxHedges+1)
+		launch := func(first bool) error {
+			hedge := req.Clone(ctx)
+			if !first && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return err
+				}
+				hedge.Body = body
+			}
+			go func() {
+				resp, err := next(hedge)
+				results <- hedgeResult{resp, err}
+			}()
+			return nil
+		}
+
+		if err := launch(true); err != nil {
+			return nil, err
+		}
+		inFlight, hedges := 1, 0
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		var last hedgeResult
+		for inFlight > 0 {
+			select {
+			case <-timer.C:
+				if hedges < maxHedges && launch(false) == nil {
+					inFlight++
+					hedges++
+					timer.Reset(delay)
+				}
+			case result := <-results:
+				inFlight--
+				if result.err == nil && !result.resp.IsServerError() {
+					return result.resp, nil
+				}
+				last = result
+			}
+		}
+		return last.resp, last.err
+	}
+}
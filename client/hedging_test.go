@@ -0,0 +1,73 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_Hedging(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("hedged"))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithHedging(20*time.Millisecond, 1))
+	start := time.Now()
+	resp, err := c.Get(server.URL, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hedged", resp.StringBody())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHttpClient_HedgingNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithHedging(10*time.Millisecond, 2))
+	resp, err := c.Post(server.URL, map[string]string{}, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHttpClient_HedgingAllFail(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := calls.Add(1)
+		// the first request finishes last
+		if call == 1 {
+			time.Sleep(80 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(strconv.Itoa(int(call))))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithHedging(20*time.Millisecond, 1))
+	resp, err := c.Get(server.URL, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "1", resp.StringBody())
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -2,36 +2,55 @@ package client
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"fmt"
-	"github.com/goccy/go-json"
+	"go.uber.org/zap"
 	"io"
-	"log"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"slices"
+	"strings"
 )
 
-const limitReadSize = 10 * 1024 * 1024 // 10M
-
 type HttpClient struct {
 	http.Client
-}
+	Retry        *RetryPolicy
+	interceptors []Interceptor
+	cache        Interceptor
+	baseURL      string
+	header       http.Header
+	query        url.Values
+
+	// ownsTransport is set once the transport is a private copy that options may change
+	ownsTransport bool
+
+	compressMinSize int
+	acceptEncoding  []string
 
-func NewHttpClient() HttpClient {
-	return HttpClient{Client: http.Client{}}
+	logger *httpLogger
 }
 
-func NewTLSHttpClient() HttpClient {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+func NewHttpClient(opts ...Option) HttpClient {
+	c := HttpClient{Client: http.Client{}}
+	for _, opt := range opts {
+		opt(&c)
 	}
-	return HttpClient{Client: http.Client{Transport: transport}}
+	return c
+}
+
+func NewTLSHttpClient(opts ...Option) HttpClient {
+	return NewHttpClient(append([]Option{WithInsecureSkipVerify()}, opts...)...)
 }
 
 func (c *HttpClient) Get(baseUrl string, params map[string]string, header http.Header) (*Response, error) {
-	u, err := url.Parse(baseUrl)
+	return c.GetContext(context.Background(), baseUrl, params, header)
+}
+
+func (c *HttpClient) GetContext(ctx context.Context, baseUrl string, params map[string]string, header http.Header, opts ...RequestOption) (*Response, error) {
+	u, err := c.resolveURL(baseUrl)
 	if err != nil {
-		log.Printf("Error Parse url: %v", err)
+		c.logError("error parsing url", err, zap.String("url", baseUrl))
 		return nil, err
 	}
 	values := u.Query()
@@ -40,123 +59,291 @@ func (c *HttpClient) Get(baseUrl string, params map[string]string, header http.H
 	}
 	u.RawQuery = values.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := c.newRequest(ctx, http.MethodGet, u.String(), nil, header, opts)
 	if err != nil {
-		log.Printf("Error creating request: %v", err)
+		c.logError("error creating request", err, zap.String("method", http.MethodGet), zap.String("url", baseUrl))
 		return nil, err
 	}
-
-	if header != nil {
-		for k, vs := range header {
-			for _, v := range vs {
-				req.Header.Add(k, v)
-			}
-		}
-	}
 	return c.execute(req)
 }
 
 func (c *HttpClient) Post(baseUrl string, payload interface{}, header http.Header) (*Response, error) {
-	payloadBytes, err := json.Marshal(payload)
+	return c.PostContext(context.Background(), baseUrl, payload, header)
+}
+
+func (c *HttpClient) PostContext(ctx context.Context, baseUrl string, payload interface{}, header http.Header, opts ...RequestOption) (*Response, error) {
+	return c.send(ctx, http.MethodPost, baseUrl, payload, header, opts...)
+}
+
+func (c *HttpClient) Put(urlPath string, payload interface{}, header http.Header) (*Response, error) {
+	return c.PutContext(context.Background(), urlPath, payload, header)
+}
+
+func (c *HttpClient) PutContext(ctx context.Context, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) (*Response, error) {
+	return c.send(ctx, http.MethodPut, urlPath, payload, header, opts...)
+}
+
+func (c *HttpClient) Patch(urlPath string, payload interface{}, header http.Header) (*Response, error) {
+	return c.PatchContext(context.Background(), urlPath, payload, header)
+}
+
+func (c *HttpClient) PatchContext(ctx context.Context, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) (*Response, error) {
+	return c.send(ctx, http.MethodPatch, urlPath, payload, header, opts...)
+}
+
+func (c *HttpClient) Delete(urlPath string, header http.Header) (*Response, error) {
+	return c.DeleteContext(context.Background(), urlPath, header)
+}
+
+func (c *HttpClient) DeleteContext(ctx context.Context, urlPath string, header http.Header, opts ...RequestOption) (*Response, error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, urlPath, nil, header, opts)
 	if err != nil {
-		fmt.Println("Error marshalling payload:", err)
+		c.logError("error creating request", err, zap.String("method", http.MethodDelete), zap.String("url", urlPath))
 		return nil, err
 	}
+	return c.execute(req)
+}
 
-	req, err := http.NewRequest(http.MethodPost, baseUrl, bytes.NewBuffer(payloadBytes))
+func (c *HttpClient) send(ctx context.Context, method, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) (*Response, error) {
+	req, err := c.newPayloadRequest(ctx, method, urlPath, payload, header, opts)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
+		c.logError("error creating request", err, zap.String("method", method), zap.String("url", urlPath))
 		return nil, err
 	}
-	if header != nil {
-		for k, vs := range header {
-			for _, v := range vs {
-				req.Header.Add(k, v)
-			}
-		}
+	return c.execute(req)
+}
+
+// NewRequest builds a request the way the verb methods do: relative paths are
+// resolved against the base URL, default headers and query parameters are added
+// and a non-nil payload is encoded as the body.
+func (c *HttpClient) NewRequest(ctx context.Context, method, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) (*http.Request, error) {
+	if payload == nil {
+		return c.newRequest(ctx, method, urlPath, nil, header, opts)
 	}
+	return c.newPayloadRequest(ctx, method, urlPath, payload, header, opts)
+}
+
+// Execute sends req through the retry policy and the interceptor chain and buffers the response.
+func (c *HttpClient) Execute(req *http.Request) (*Response, error) {
 	return c.execute(req)
 }
 
-func (c *HttpClient) Put(urlPath string, payload interface{}, header http.Header) (*Response, error) {
-	payloadBytes, err := json.Marshal(payload)
+func (c *HttpClient) newPayloadRequest(ctx context.Context, method, urlPath string, payload interface{}, header http.Header, opts []RequestOption) (*http.Request, error) {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = c.header.Get("Content-Type")
+	}
+	reader, contentType, err := encodePayload(payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, method, urlPath, nil, header, opts)
 	if err != nil {
-		fmt.Println("Error marshalling payload:", err)
 		return nil, err
 	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
-	req, err := http.NewRequest(http.MethodPut, urlPath, bytes.NewBuffer(payloadBytes))
+	// a retried request needs its body again, anything else the caller handed over as a reader is streamed
+	if !inMemory(reader) && !c.Retry.replays() {
+		req.Body = c.compressStream(reader, req.Header)
+		return req, nil
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
 		return nil, err
 	}
-	if header != nil {
-		for k, vs := range header {
-			for _, v := range vs {
-				req.Header.Add(k, v)
+	if body, err = c.compressBody(body, req.Header); err != nil {
+		return nil, err
+	}
+	setBody(req, body)
+	return req, nil
+}
+
+func (c *HttpClient) newRequest(ctx context.Context, method, urlPath string, body io.Reader, header http.Header, opts []RequestOption) (*http.Request, error) {
+	u, err := c.resolveURL(urlPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.query) > 0 {
+		values := u.Query()
+		for key, vs := range c.query {
+			if !values.Has(key) {
+				values[key] = vs
 			}
 		}
+		u.RawQuery = values.Encode()
 	}
-	return c.execute(req)
-}
 
-func (c *HttpClient) Patch(urlPath string, payload interface{}, header http.Header) (*Response, error) {
-	payloadBytes, err := json.Marshal(payload)
+	req, err := http.NewRequestWithContext(withRequestOptions(ctx, opts), method, u.String(), body)
 	if err != nil {
-		fmt.Println("Error marshalling payload:", err)
 		return nil, err
 	}
-	req, err := http.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer(payloadBytes))
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	for k, vs := range c.header {
+		if req.Header.Get(k) == "" {
+			req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), vs...)
+		}
+	}
+	accept, err := c.acceptEncodingHeader()
 	if err != nil {
-		fmt.Println("Error creating request:", err)
 		return nil, err
 	}
-	if header != nil {
-		for k, vs := range header {
-			for _, v := range vs {
-				req.Header.Add(k, v)
-			}
+	if accept != "" && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", accept)
+	}
+	return req, nil
+}
+
+// setBody sets a replayable in-memory body, like http.NewRequest does for a *bytes.Reader.
+func setBody(req *http.Request, body []byte) {
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	if len(body) == 0 {
+		req.Body = http.NoBody
+		req.GetBody = func() (io.ReadCloser, error) {
+			return http.NoBody, nil
 		}
 	}
-	return c.execute(req)
 }
 
-func (c *HttpClient) Delete(urlPath string, header http.Header) (*Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, urlPath, nil)
+// resolveURL joins a relative urlPath onto the base URL; absolute URLs are kept as they are.
+func (c *HttpClient) resolveURL(urlPath string) (*url.URL, error) {
+	u, err := url.Parse(urlPath)
+	if err != nil || c.baseURL == "" || u.IsAbs() {
+		return u, err
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := joinPath(base, u.EscapedPath())
 	if err != nil {
-		fmt.Println("Error creating request:", err)
 		return nil, err
 	}
-	if header != nil {
-		for k, vs := range header {
-			for _, v := range vs {
-				req.Header.Add(k, v)
+	resolved.RawQuery = u.RawQuery
+	resolved.Fragment = u.Fragment
+	return resolved, nil
+}
+
+// joinPath appends the escaped relative path to the path of base. Escaped
+// slashes are kept and dot segments are resolved within the relative part only,
+// so a path can never climb out of the base path.
+func joinPath(base *url.URL, relative string) (*url.URL, error) {
+	if relative == "" {
+		resolved := *base
+		return &resolved, nil
+	}
+	var segments []string
+	parts := strings.Split(strings.TrimPrefix(relative, "/"), "/")
+	for i, segment := range parts {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case unescaped == "..":
+			if len(segments) == 0 {
+				return nil, fmt.Errorf("path %q escapes the base URL", relative)
 			}
+			segments = segments[:len(segments)-1]
+		case unescaped == "." || (segment == "" && i < len(parts)-1):
+		default:
+			segments = append(segments, segment)
 		}
 	}
 
-	return c.execute(req)
+	escaped := strings.TrimSuffix(base.EscapedPath(), "/") + "/" + strings.Join(segments, "/")
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *base
+	resolved.Path, resolved.RawPath = path, escaped
+	return &resolved, nil
 }
 
 func (c *HttpClient) execute(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+	retryable := c.Retry.canRetry(req)
+	interceptors := c.interceptors
+	if c.cache != nil {
+		interceptors = append(slices.Clip(interceptors), c.cache)
+	}
+	handler := chain(interceptors, c.roundTrip)
+
+	for attempt := 1; ; attempt++ {
+		// every attempt works on its own copy so interceptors never see the headers of a previous one
+		attemptReq := req
+		if retryable {
+			attemptReq = req.Clone(ctx)
+			if attempt > 1 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := handler(attemptReq)
+		if !retryable || attempt >= c.Retry.MaxAttempts || !c.Retry.shouldRetry(resp, err) {
+			return resp, err
+		}
+		resp.close()
+		if err := sleep(ctx, c.Retry.backoff(attempt+1, resp)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *HttpClient) roundTrip(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	options := requestOptionsFrom(ctx)
+	trace := newTimingTrace()
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace.clientTrace()))
+
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		fmt.Println("Error sending request:", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	limitReader := io.LimitReader(resp.Body, limitReadSize)
-	body, err := io.ReadAll(limitReader)
+	reader, err := c.decodeBody(resp)
 	if err != nil {
-		fmt.Println("Error reading response body:", err)
 		return nil, err
 	}
-
-	return &Response{
+	response := &Response{
 		Status:     resp.Status,
 		StatusCode: resp.StatusCode,
 		Header:     resp.Header,
-		Body:       body,
-	}, nil
+	}
+	if options.stream {
+		response.stream = limitBody(reader, options.bodyLimit())
+		response.Timing = trace.finish()
+		return response, nil
+	}
+	defer reader.Close()
+
+	body, err := readBody(reader, options.bodyLimit())
+	if err != nil {
+		// a cancelled context surfaces as a generic read error, report the cause instead
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	response.Body = body
+	response.Timing = trace.finish()
+	return response, nil
 }
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_GetContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewHttpClient()
+	_, err := c.GetContext(ctx, server.URL, nil, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHttpClient_PostContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "value", r.Header.Get("X-Test"))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	resp, err := c.PostContext(context.Background(), server.URL, map[string]string{"name": "test"}, http.Header{"X-Test": {"value"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestHttpClient_Retry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	c.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	resp, err = c.Post(server.URL, map[string]string{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	resp, err = c.Post(server.URL, map[string]string{}, http.Header{"Idempotency-Key": {"key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestHttpClient_Use(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Trace-Id") + "," + r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	var order []string
+	c := NewHttpClient()
+	c.Use(
+		func(req *http.Request, next Handler) (*Response, error) {
+			order = append(order, "outer")
+			req.Header.Set("X-Trace-Id", "trace")
+			return next(req)
+		},
+		HeaderInterceptor(http.Header{"Authorization": {"Bearer token"}}),
+		func(req *http.Request, next Handler) (*Response, error) {
+			order = append(order, "inner")
+			resp, err := next(req)
+			order = append(order, "response")
+			return resp, err
+		},
+	)
+
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "trace,Bearer token", resp.StringBody())
+	assert.Equal(t, []string{"outer", "inner", "response"}, order)
+}
+
+func TestRetryPolicy_BackoffCapsRetryAfter(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: time.Second}
+	resp := &Response{Header: http.Header{"Retry-After": {"86400"}}}
+
+	assert.Equal(t, time.Second, p.backoff(2, resp))
+
+	p.MaxBackoff = 0
+	assert.Equal(t, 24*time.Hour, p.backoff(2, resp))
+}
@@ -0,0 +1,40 @@
+package client
+
+import "net/http"
+
+// Handler sends a request and returns its response. The body of a response
+// requested through Stream is left unread, so Body is nil there.
+type Handler func(req *http.Request) (*Response, error)
+
+// Interceptor wraps the sending of a request. It may change the request before
+// calling next, inspect or replace the response, or skip next altogether.
+type Interceptor func(req *http.Request, next Handler) (*Response, error)
+
+// Use appends interceptors to the chain. The first registered interceptor is the
+// outermost one; the last one sees the request right before it is sent. With a
+// retry policy the chain runs once per attempt.
+func (c *HttpClient) Use(interceptors ...Interceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+func chain(interceptors []Interceptor, handler Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(req *http.Request) (*Response, error) {
+			return interceptor(req, next)
+		}
+	}
+	return handler
+}
+
+// HeaderInterceptor sets the given headers on every request that does not already carry them.
+func HeaderInterceptor(header http.Header) Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		for k, vs := range header {
+			if req.Header.Get(k) == "" {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), vs...)
+			}
+		}
+		return next(req)
+	}
+}
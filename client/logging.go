@@ -0,0 +1,174 @@
+package client
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/huhx/common-go/logger"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const redactedValue = "***"
+
+// Redaction masks sensitive values before requests and responses are logged.
+// Authorization, Proxy-Authorization, Cookie and Set-Cookie headers are always masked.
+type Redaction struct {
+	Headers    []string // extra header names to mask
+	Fields     []string // JSON body fields and query parameters to mask, matched case-insensitively at any depth
+	LogBodies  bool     // log request and response bodies
+	MaxBodyLog int      // bytes of each body to log, default 2K
+}
+
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// SensitiveHeaders returns the headers that carry credentials and are always redacted.
+func SensitiveHeaders() []string {
+	return slices.Clone(sensitiveHeaders)
+}
+
+// WithLogger logs every request attempt with l: method, URL, status, latency
+// and sizes, plus the bodies when redaction.LogBodies is set.
+func WithLogger(l *logger.Logger, redaction Redaction) Option {
+	return func(c *HttpClient) {
+		c.logger = &httpLogger{logger: l, redaction: redaction}
+		c.Use(c.logger.interceptor)
+	}
+}
+
+type httpLogger struct {
+	logger    *logger.Logger
+	redaction Redaction
+}
+
+func (c *HttpClient) logError(msg string, err error, fields ...zap.Field) {
+	if c.logger != nil {
+		c.logger.logger.Error(msg, append(fields, zap.Error(err))...)
+	}
+}
+
+func (l *httpLogger) interceptor(req *http.Request, next Handler) (*Response, error) {
+	start := time.Now()
+	resp, err := next(req)
+
+	fields := []zap.Field{
+		zap.String("method", req.Method),
+		zap.String("url", l.redactURL(req.URL)),
+		zap.Duration("latency", time.Since(start)),
+		zap.Int64("requestSize", max(req.ContentLength, 0)),
+		zap.Any("requestHeader", l.redactHeader(req.Header)),
+	}
+	if l.redaction.LogBodies {
+		fields = append(fields, zap.String("requestBody", l.redactBody(requestBody(req))))
+	}
+	if err != nil {
+		l.logger.Error("http request failed", append(fields, zap.Error(err))...)
+		return resp, err
+	}
+
+	fields = append(fields,
+		zap.Int("status", resp.StatusCode),
+		zap.Int("responseSize", len(resp.Body)),
+		zap.Any("responseHeader", l.redactHeader(resp.Header)),
+	)
+	if l.redaction.LogBodies && resp.stream == nil {
+		fields = append(fields, zap.String("responseBody", l.redactBody(resp.Body)))
+	}
+	switch {
+	case resp.IsServerError():
+		l.logger.Error("http request completed", fields...)
+	case resp.IsClientError():
+		l.logger.Warn("http request completed", fields...)
+	default:
+		l.logger.Info("http request completed", fields...)
+	}
+	return resp, nil
+}
+
+func (l *httpLogger) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range slices.Concat(sensitiveHeaders, l.redaction.Headers) {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+func (l *httpLogger) redactURL(u *url.URL) string {
+	if len(l.redaction.Fields) == 0 || u.RawQuery == "" {
+		return u.Redacted()
+	}
+	values := u.Query()
+	for key := range values {
+		if l.isRedactedField(key) {
+			values.Set(key, redactedValue)
+		}
+	}
+	redacted := *u
+	redacted.RawQuery = values.Encode()
+	return redacted.Redacted()
+}
+
+func (l *httpLogger) redactBody(body []byte) string {
+	maxBodyLog := l.redaction.MaxBodyLog
+	if maxBodyLog <= 0 {
+		maxBodyLog = 2 * 1024
+	}
+
+	if len(l.redaction.Fields) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			if redacted, err := json.Marshal(l.redactValue(value)); err == nil {
+				body = redacted
+			}
+		}
+	}
+	if len(body) > maxBodyLog {
+		return string(body[:maxBodyLog]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+func (l *httpLogger) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if l.isRedactedField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = l.redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = l.redactValue(item)
+		}
+	}
+	return value
+}
+
+func (l *httpLogger) isRedactedField(name string) bool {
+	for _, field := range l.redaction.Fields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	return data
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"github.com/huhx/common-go/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttpClient_WithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte(`{"user":{"name":"huhx","password":"secret"}}`))
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	c := NewHttpClient(WithLogger(logger.NewLoggerWithZap(zap.New(core)), Redaction{
+		Headers:   []string{"X-Api-Key"},
+		Fields:    []string{"password", "token"},
+		LogBodies: true,
+	}))
+
+	_, err := c.Get(server.URL+"?token=secret&page=1", nil, http.Header{"Authorization": {"Bearer secret"}, "X-Api-Key": {"secret"}})
+	assert.NoError(t, err)
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "http request completed", entries[0].Message)
+	assert.Equal(t, int64(200), fields["status"])
+	assert.Equal(t, server.URL+"?page=1&token=%2A%2A%2A", fields["url"])
+	assert.Equal(t, `{"user":{"name":"huhx","password":"***"}}`, fields["responseBody"])
+	for _, field := range entries[0].Context {
+		if header, ok := field.Interface.(http.Header); ok {
+			for _, values := range header {
+				assert.NotContains(t, values, "secret")
+				assert.NotContains(t, values, "Bearer secret")
+				assert.NotContains(t, values, "session=secret")
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Timing breaks down where the time of one request went. Phases that did not
+// happen, such as DNS and Connect on a reused connection, stay zero. Total ends
+// when the body has been read, or when the headers arrived for a streamed response.
+type Timing struct {
+	DNS             time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	Total           time.Duration
+	ConnReused      bool
+}
+
+type timingTrace struct {
+	mu                                   sync.Mutex
+	start, dnsStart, connStart, tlsStart time.Time
+	timing                               Timing
+}
+
+func newTimingTrace() *timingTrace {
+	return &timingTrace{start: time.Now()}
+}
+
+func (t *timingTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.done(&t.dnsStart, &t.timing.DNS) },
+		ConnectStart:         func(string, string) { t.mark(&t.connStart) },
+		ConnectDone:          func(string, string, error) { t.done(&t.connStart, &t.timing.Connect) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.done(&t.tlsStart, &t.timing.TLSHandshake) },
+		GotConn:              t.gotConn,
+		GotFirstResponseByte: func() { t.done(&t.start, &t.timing.TimeToFirstByte) },
+	}
+}
+
+func (t *timingTrace) gotConn(info httptrace.GotConnInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.ConnReused = info.Reused
+}
+
+func (t *timingTrace) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = time.Now()
+}
+
+// done measures phase from the time at since, which mark may still be writing
+// from another connection attempt, so it is only read under the lock.
+func (t *timingTrace) done(since *time.Time, phase *time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*phase = time.Since(*since)
+}
+
+func (t *timingTrace) finish() Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Total = time.Since(t.start)
+	return t.timing
+}
+
+// Metrics receives one observation per request attempt. StatusClass is "2xx"
+// to "5xx", or "error" when no response was received. A Prometheus adapter
+// typically increments a counter and observes histograms per phase here.
+type Metrics interface {
+	Observe(host, statusClass string, timing Timing)
+}
+
+// WithMetrics reports every request attempt to metrics.
+func WithMetrics(metrics Metrics) Option {
+	return WithInterceptors(func(req *http.Request, next Handler) (*Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		if err != nil {
+			metrics.Observe(req.URL.Host, "error", Timing{Total: time.Since(start)})
+			return resp, err
+		}
+		metrics.Observe(req.URL.Host, StatusClass(resp.StatusCode), resp.Timing)
+		return resp, nil
+	})
+}
+
+func StatusClass(statusCode int) string {
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// DefaultLatencyBuckets are the upper bounds of the InMemoryMetrics histograms.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// InMemoryMetrics aggregates request counts and a latency histogram of the total
+// time per host and status class.
+type InMemoryMetrics struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	series  map[MetricsKey]*MetricsSeries
+}
+
+type MetricsKey struct {
+	Host        string
+	StatusClass string
+}
+
+// MetricsSeries holds the aggregates of one MetricsKey. Buckets[i] counts the
+// requests whose total time was at most the i-th bucket bound; the last entry
+// counts the slower ones.
+type MetricsSeries struct {
+	Count   int64
+	Sum     time.Duration
+	Buckets []int64
+}
+
+func NewInMemoryMetrics(buckets ...time.Duration) *InMemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &InMemoryMetrics{buckets: buckets, series: make(map[MetricsKey]*MetricsSeries)}
+}
+
+func (m *InMemoryMetrics) Observe(host, statusClass string, timing Timing) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := MetricsKey{Host: host, StatusClass: statusClass}
+	series, ok := m.series[key]
+	if !ok {
+		series = &MetricsSeries{Buckets: make([]int64, len(m.buckets)+1)}
+		m.series[key] = series
+	}
+	series.Count++
+	series.Sum += timing.Total
+	index, _ := slices.BinarySearch(m.buckets, timing.Total)
+	series.Buckets[index]++
+}
+
+// Snapshot returns a copy of the aggregates collected so far.
+func (m *InMemoryMetrics) Snapshot() map[MetricsKey]MetricsSeries {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[MetricsKey]MetricsSeries, len(m.series))
+	for key, series := range m.series {
+		snapshot[key] = MetricsSeries{Count: series.Count, Sum: series.Sum, Buckets: slices.Clone(series.Buckets)}
+	}
+	return snapshot
+}
+
+// Buckets returns the histogram bucket bounds.
+func (m *InMemoryMetrics) Buckets() []time.Duration {
+	return slices.Clone(m.buckets)
+}
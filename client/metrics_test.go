@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHttpClient_WithMetrics(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host := func() string { u, _ := url.Parse(server.URL); return u.Host }()
+
+	metrics := NewInMemoryMetrics(time.Minute)
+	c := NewTLSHttpClient(WithMetrics(metrics))
+
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Greater(t, resp.Timing.Connect, time.Duration(0))
+	assert.Greater(t, resp.Timing.TLSHandshake, time.Duration(0))
+	assert.GreaterOrEqual(t, resp.Timing.Total, resp.Timing.TimeToFirstByte)
+	assert.False(t, resp.Timing.ConnReused)
+
+	resp, err = c.Get(server.URL+"/missing", nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.Timing.ConnReused)
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, int64(1), snapshot[MetricsKey{Host: host, StatusClass: "2xx"}].Count)
+	assert.Equal(t, []int64{1, 0}, snapshot[MetricsKey{Host: host, StatusClass: "4xx"}].Buckets)
+}
+
+// Happy Eyeballs dials in parallel, so trace hooks run concurrently; go test -race
+// reports any unguarded access.
+func TestTimingTrace_Concurrent(t *testing.T) {
+	trace := newTimingTrace().clientTrace()
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			<-start
+			for range 100 {
+				trace.ConnectStart("tcp", "127.0.0.1:80")
+				runtime.Gosched()
+				trace.ConnectDone("tcp", "127.0.0.1:80", nil)
+			}
+		})
+	}
+	close(start)
+	wg.Wait()
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures an HttpClient built by NewHttpClient.
+type Option func(*HttpClient)
+
+// WithBaseURL resolves relative request paths against baseURL, keeping its path
+// prefix: with https://api.example.com/v1 a request to "users" goes to
+// https://api.example.com/v1/users.
+func WithBaseURL(baseURL string) Option {
+	return func(c *HttpClient) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHeader adds headers sent with every request unless the request sets them itself.
+func WithHeader(header http.Header) Option {
+	return func(c *HttpClient) {
+		if c.header == nil {
+			c.header = make(http.Header)
+		}
+		for k, vs := range header {
+			c.header[http.CanonicalHeaderKey(k)] = append(c.header[http.CanonicalHeaderKey(k)], vs...)
+		}
+	}
+}
+
+// WithQuery adds query parameters sent with every request unless the request URL sets them itself.
+func WithQuery(query url.Values) Option {
+	return func(c *HttpClient) {
+		if c.query == nil {
+			c.query = make(url.Values)
+		}
+		for k, vs := range query {
+			c.query[k] = append(c.query[k], vs...)
+		}
+	}
+}
+
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *HttpClient) {
+		c.Retry = policy
+	}
+}
+
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *HttpClient) {
+		c.Use(interceptors...)
+	}
+}
+
+// WithTransport replaces the transport. Transport tuning options given after it
+// only apply when it is an *http.Transport, and then to a copy of it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *HttpClient) {
+		c.Transport = transport
+		c.ownsTransport = false
+	}
+}
+
+// WithTimeout limits the whole exchange, including reading the body, like http.Client.Timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *HttpClient) {
+		c.Timeout = timeout
+	}
+}
+
+func WithDialTimeout(timeout time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+		t.DialContext = dialer.DialContext
+	})
+}
+
+func WithTLSHandshakeTimeout(timeout time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		t.TLSHandshakeTimeout = timeout
+	})
+}
+
+func WithResponseHeaderTimeout(timeout time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		t.ResponseHeaderTimeout = timeout
+	})
+}
+
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		t.IdleConnTimeout = timeout
+	})
+}
+
+func WithMaxIdleConns(total, perHost int) Option {
+	return withTransport(func(t *http.Transport) {
+		t.MaxIdleConns = total
+		t.MaxIdleConnsPerHost = perHost
+	})
+}
+
+func WithMaxConnsPerHost(max int) Option {
+	return withTransport(func(t *http.Transport) {
+		t.MaxConnsPerHost = max
+	})
+}
+
+// WithProxy sets the proxy used for each request, see http.ProxyFromEnvironment and http.ProxyURL.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return withTransport(func(t *http.Transport) {
+		t.Proxy = proxy
+	})
+}
+
+func WithProxyURL(proxyURL *url.URL) Option {
+	return WithProxy(http.ProxyURL(proxyURL))
+}
+
+// WithRootCAs trusts the given certificate pool instead of the system one.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return withTLSConfig(func(config *tls.Config) {
+		config.RootCAs = pool
+	})
+}
+
+// WithClientCertificates presents the given certificates for mutual TLS.
+func WithClientCertificates(certificates ...tls.Certificate) Option {
+	return withTLSConfig(func(config *tls.Config) {
+		config.Certificates = append(config.Certificates, certificates...)
+	})
+}
+
+func WithInsecureSkipVerify() Option {
+	return withTLSConfig(func(config *tls.Config) {
+		config.InsecureSkipVerify = true
+	})
+}
+
+// WithHTTP2 enables or disables HTTP/2 negotiation over TLS.
+func WithHTTP2(enabled bool) Option {
+	return withTransport(func(t *http.Transport) {
+		t.ForceAttemptHTTP2 = enabled
+		if enabled {
+			t.TLSNextProto = nil
+		} else {
+			t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+	})
+}
+
+func withTLSConfig(configure func(*tls.Config)) Option {
+	return withTransport(func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		configure(t.TLSClientConfig)
+	})
+}
+
+// withTransport tunes the client's *http.Transport. The first time it copies the
+// transport, http.DefaultTransport when none was set, so a transport shared with
+// other clients is never changed.
+func withTransport(configure func(*http.Transport)) Option {
+	return func(c *HttpClient) {
+		if c.Transport == nil {
+			c.Transport = http.DefaultTransport
+		}
+		transport, ok := c.Transport.(*http.Transport)
+		if !ok {
+			return
+		}
+		if !c.ownsTransport {
+			transport = transport.Clone()
+			c.Transport = transport
+			c.ownsTransport = true
+		}
+		configure(transport)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewHttpClient_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.String() + "," + r.Header.Get("X-App")))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(
+		WithBaseURL(server.URL+"/api/v1"),
+		WithHeader(http.Header{"X-App": {"common"}}),
+		WithQuery(url.Values{"lang": {"en"}}),
+		WithTimeout(time.Second),
+		WithDialTimeout(time.Second),
+		WithMaxIdleConns(10, 2),
+		WithHTTP2(false),
+	)
+
+	resp, err := c.Get("/users", map[string]string{"id": "1"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/users?id=1&lang=en,common", resp.StringBody())
+
+	resp, err = c.Get(server.URL+"/health?lang=zh", nil, http.Header{"X-App": {"other"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/health?lang=zh,other", resp.StringBody())
+
+	transport := c.Transport.(*http.Transport)
+	assert.Equal(t, 2, transport.MaxIdleConnsPerHost)
+	assert.False(t, transport.ForceAttemptHTTP2)
+}
+
+func TestNewTLSHttpClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	c := NewTLSHttpClient()
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+}
+
+func TestWithTransport_Copied(t *testing.T) {
+	shared := &http.Transport{}
+	c := NewHttpClient(WithTransport(shared), WithInsecureSkipVerify(), WithMaxConnsPerHost(4))
+
+	transport := c.Transport.(*http.Transport)
+	assert.NotSame(t, shared, transport)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, 4, transport.MaxConnsPerHost)
+	assert.True(t, shared.TLSClientConfig == nil || !shared.TLSClientConfig.InsecureSkipVerify)
+	assert.Zero(t, shared.MaxConnsPerHost)
+
+	NewHttpClient(WithTransport(http.DefaultTransport), WithInsecureSkipVerify())
+	defaultConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig
+	assert.True(t, defaultConfig == nil || !defaultConfig.InsecureSkipVerify)
+}
+
+func TestHttpClient_ResolveURL(t *testing.T) {
+	c := NewHttpClient(WithBaseURL("https://api.example.com/v1/"))
+	tests := []struct {
+		path     string
+		want     string
+		hasError bool
+	}{
+		{path: "/users", want: "https://api.example.com/v1/users"},
+		{path: "users/?page=2", want: "https://api.example.com/v1/users/?page=2"},
+		{path: "files/a%2Fb", want: "https://api.example.com/v1/files/a%2Fb"},
+		{path: "files/./a/../b", want: "https://api.example.com/v1/files/b"},
+		{path: "?q=1", want: "https://api.example.com/v1/?q=1"},
+		{path: "../admin", hasError: true},
+		{path: "files/%2e%2e/%2e%2e/admin", hasError: true},
+		{path: "https://other.example.com/x", want: "https://other.example.com/x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			u, err := c.resolveURL(tt.path)
+
+			assert.Equal(t, tt.hasError, err != nil)
+			if !tt.hasError {
+				assert.Equal(t, tt.want, u.String())
+			}
+		})
+	}
+}
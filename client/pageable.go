@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"github.com/huhx/common-go/base"
+	"iter"
+	"net/http"
+)
+
+// PageRequest builds the request fetching one page, usually with HttpClient.NewRequest.
+type PageRequest func(ctx context.Context, page base.Pageable) (*http.Request, error)
+
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+	prefetch bool
+}
+
+// WithPrefetch fetches the next page in the background while the current one is consumed.
+func WithPrefetch() PageOption {
+	return func(o *pageOptions) {
+		o.prefetch = true
+	}
+}
+
+type pageResult[T any] struct {
+	page *base.PageableResponse[T]
+	err  error
+}
+
+// Paginate walks a remote base.PageableResponse API lazily, starting at page and
+// following NextPage while HasNext is true. A failed page is yielded as an error
+// and ends the iteration.
+func Paginate[T any](ctx context.Context, c *HttpClient, page base.Pageable, request PageRequest, opts ...PageOption) iter.Seq2[T, error] {
+	var options pageOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fetch := func(page base.Pageable) (*base.PageableResponse[T], error) {
+			req, err := request(ctx, page)
+			if err != nil {
+				return nil, err
+			}
+			return decodeJSON[base.PageableResponse[T]](c.Execute(req))
+		}
+
+		current, err := fetch(page)
+		for {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			var next chan pageResult[T]
+			if options.prefetch && current.HasNext() {
+				next = make(chan pageResult[T], 1)
+				go func(page base.Pageable) {
+					resp, err := fetch(page)
+					next <- pageResult[T]{resp, err}
+				}(current.NextPage())
+			}
+
+			for _, item := range current.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !current.HasNext() {
+				return
+			}
+
+			if next != nil {
+				result := <-next
+				current, err = result.page, result.err
+			} else {
+				current, err = fetch(current.NextPage())
+			}
+		}
+	}
+}
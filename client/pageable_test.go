@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/goccy/go-json"
+	"github.com/huhx/common-go/base"
+	"github.com/huhx/common-go/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := base.NewPageable(r.URL.Query().Get("pageIndex"), r.URL.Query().Get("pageSize"))
+		end := min(page.Offset()+page.Limit(), len(items))
+		body, _ := json.Marshal(base.NewPageableResponse(items[page.Offset():end], int64(len(items)), page))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithBaseURL(server.URL))
+	request := func(ctx context.Context, page base.Pageable) (*http.Request, error) {
+		return c.NewRequest(ctx, http.MethodGet, "/items?pageIndex="+util.IntToString(page.PageIndex)+"&pageSize="+util.IntToString(page.PageSize), nil, nil)
+	}
+
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		var result []int
+		for item, err := range Paginate[int](context.Background(), &c, base.Pageable{PageSize: 2}, request, opts...) {
+			assert.NoError(t, err)
+			result = append(result, item)
+		}
+		assert.Equal(t, items, result)
+	}
+
+	var first []int
+	for item := range Paginate[int](context.Background(), &c, base.Pageable{PageSize: 2}, request, WithPrefetch()) {
+		first = append(first, item)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3}, first)
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TokenBucket refills Rate tokens per second up to Burst; a non-positive rate
+// never runs out of tokens but can still be paused.
+type TokenBucket struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	burst = max(burst, 1)
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.reserve()
+		if wait == 0 {
+			return nil
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// PauseUntil holds back every token until t, e.g. after the upstream answered 429.
+func (b *TokenBucket) PauseUntil(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t.After(b.pausedUntil) {
+		b.pausedUntil = t
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before trying again.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// RateLimitConfig limits requests per second and requests in flight; zero means unlimited.
+type RateLimitConfig struct {
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+}
+
+// RateLimiter applies a client wide limit plus a limit per upstream host. When a
+// host answers 429 with Retry-After, further requests to it wait that long. A
+// streamed response holds its in-flight slots until its body is closed.
+type RateLimiter struct {
+	client      *limit
+	perHost     RateLimitConfig
+	mu          sync.Mutex
+	hostConfigs map[string]RateLimitConfig
+	hosts       map[string]*limit
+}
+
+type limit struct {
+	bucket *TokenBucket
+	slots  chan struct{}
+}
+
+func newLimit(config RateLimitConfig) *limit {
+	l := &limit{bucket: NewTokenBucket(config.Rate, config.Burst)}
+	if config.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, config.MaxInFlight)
+	}
+	return l
+}
+
+func (l *limit) acquire(ctx context.Context) error {
+	if err := l.bucket.Wait(ctx); err != nil {
+		return err
+	}
+	if l.slots == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *limit) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// NewRateLimiter limits the whole client with client and every host with perHost.
+func NewRateLimiter(client, perHost RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		client:      newLimit(client),
+		perHost:     perHost,
+		hostConfigs: make(map[string]RateLimitConfig),
+		hosts:       make(map[string]*limit),
+	}
+}
+
+// SetHostLimit overrides the per host limit for host (host[:port] as in the request URL).
+func (r *RateLimiter) SetHostLimit(host string, config RateLimitConfig) *RateLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hostConfigs[host] = config
+	delete(r.hosts, host)
+	return r
+}
+
+// WithRateLimiter makes every request wait for limiter before it is sent.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return WithInterceptors(limiter.Interceptor())
+}
+
+func (r *RateLimiter) Interceptor() Interceptor {
+	return func(req *http.Request, next Handler) (*Response, error) {
+		ctx := req.Context()
+		host := r.host(req.URL.Host)
+
+		// wait for the host first, so a request to a paused host holds no client wide slot
+		if err := host.acquire(ctx); err != nil {
+			return nil, err
+		}
+		if err := r.client.acquire(ctx); err != nil {
+			host.release()
+			return nil, err
+		}
+		release := sync.OnceFunc(func() {
+			r.client.release()
+			host.release()
+		})
+
+		resp, err := next(req)
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			if after, ok := RetryAfter(resp.Header); ok {
+				host.bucket.PauseUntil(time.Now().Add(after))
+			}
+		}
+		if err == nil && resp.stream != nil {
+			// a streamed body is still being read, keep the slots until it is closed
+			resp.stream = &releasingBody{ReadCloser: resp.stream, release: release}
+		} else {
+			release()
+		}
+		return resp, err
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+func (r *RateLimiter) host(host string) *limit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.hosts[host]
+	if !ok {
+		config, ok := r.hostConfigs[host]
+		if !ok {
+			config = r.perHost
+		}
+		l = newLimit(config)
+		r.hosts[host] = l
+	}
+	return l
+}
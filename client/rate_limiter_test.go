@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := NewTokenBucket(100, 1)
+	start := time.Now()
+	for range 3 {
+		assert.NoError(t, bucket.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	bucket.PauseUntil(time.Now().Add(time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bucket.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiter_RetryAfter(t *testing.T) {
+	limited := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limited {
+			limited = false
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithRateLimiter(NewRateLimiter(RateLimitConfig{}, RateLimitConfig{MaxInFlight: 1})))
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.GetContext(ctx, server.URL, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_PausedHost(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	paused, healthy := httptest.NewServer(handler), httptest.NewServer(handler)
+	defer paused.Close()
+	defer healthy.Close()
+
+	limiter := NewRateLimiter(RateLimitConfig{MaxInFlight: 1}, RateLimitConfig{})
+	limiter.host(paused.Listener.Addr().String()).bucket.PauseUntil(time.Now().Add(time.Second))
+	c := NewHttpClient(WithRateLimiter(limiter))
+
+	done := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		_, err := c.GetContext(ctx, paused.URL, nil, nil)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	resp, err := c.Get(healthy.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, resp.IsSuccess())
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_StreamHoldsSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("body"))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient(WithRateLimiter(NewRateLimiter(RateLimitConfig{MaxInFlight: 1}, RateLimitConfig{})))
+	stream, err := c.Stream(context.Background(), http.MethodGet, server.URL, nil, nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.GetContext(ctx, server.URL, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, stream.Body.Close())
+	assert.NoError(t, stream.Body.Close())
+	resp, err := c.Get(server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "body", resp.StringBody())
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"math"
+)
+
+const limitReadSize = 10 * 1024 * 1024 // 10M
+
+// RequestOption tunes a single request. Options travel with the request context,
+// so they are visible to every retry attempt and interceptor.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	stream      bool
+	maxBodySize int64
+}
+
+type requestOptionsKey struct{}
+
+// WithMaxBodySize caps the response body at size bytes; a bigger body fails with
+// ErrBodyTooLarge. A negative size removes the cap. Without this option buffered
+// responses are capped at 10M and streamed ones are not capped.
+func WithMaxBodySize(size int64) RequestOption {
+	return func(o *requestOptions) {
+		o.maxBodySize = size
+	}
+}
+
+func streamed() RequestOption {
+	return func(o *requestOptions) {
+		o.stream = true
+	}
+}
+
+func withRequestOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	options := requestOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	options, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return options
+}
+
+func (o requestOptions) bodyLimit() int64 {
+	switch {
+	case o.maxBodySize > 0:
+		return o.maxBodySize
+	case o.maxBodySize < 0 || o.stream:
+		return math.MaxInt64
+	default:
+		return limitReadSize
+	}
+}
@@ -2,8 +2,8 @@ package client
 
 import (
 	"encoding/xml"
-	"fmt"
 	"github.com/goccy/go-json"
+	"io"
 	"net/http"
 )
 
@@ -12,6 +12,9 @@ type Response struct {
 	StatusCode int
 	Header     http.Header
 	Body       []byte
+	Timing     Timing
+
+	stream io.ReadCloser // unread body of a streamed response, Body is nil then
 }
 
 func (r Response) IsSuccess() bool {
@@ -26,14 +29,27 @@ func (r Response) IsServerError() bool {
 	return r.StatusCode >= 500 && r.StatusCode <= 599
 }
 
+// Err returns an *HTTPError for a non-2xx response and nil otherwise.
+func (r Response) Err() error {
+	if r.IsSuccess() {
+		return nil
+	}
+	return NewHTTPError(&r)
+}
+
 func (r Response) StringBody() string {
 	return string(r.Body)
 }
 
+func (r *Response) close() {
+	if r != nil && r.stream != nil {
+		r.stream.Close()
+	}
+}
+
 func JsonBody[R any](response *Response) (*R, error) {
 	var result R
 	if err := json.Unmarshal(response.Body, &result); err != nil {
-		fmt.Println("Error unmarshalling JSON:", err)
 		return nil, err
 	}
 	return &result, nil
@@ -42,7 +58,6 @@ func JsonBody[R any](response *Response) (*R, error) {
 func XmlBody[R any](response *Response) (*R, error) {
 	var result R
 	if err := xml.Unmarshal(response.Body, &result); err != nil {
-		fmt.Println("Error unmarshalling XML:", err)
 		return nil, err
 	}
 	return &result, nil
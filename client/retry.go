@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how HttpClient retries a failed request. Only idempotent
+// methods are retried unless RetryNonIdempotent is set or the request carries an
+// Idempotency-Key header.
+type RetryPolicy struct {
+	MaxAttempts        int           // total attempts including the first one
+	InitialBackoff     time.Duration // wait before the second attempt
+	MaxBackoff         time.Duration // upper bound of a single wait
+	Multiplier         float64       // growth factor between attempts
+	Jitter             float64       // fraction of the wait that is randomized: [0; 1]
+	RetryOn            func(resp *Response, err error) bool
+	RetryNonIdempotent bool
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryOn:        DefaultRetryOn,
+	}
+}
+
+// DefaultRetryOn retries connection resets, timeouts and 429/502/503/504 responses.
+func DefaultRetryOn(resp *Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		var netErr net.Error
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF) ||
+			(errors.As(err, &netErr) && netErr.Timeout())
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if !p.replays() {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	return p.RetryNonIdempotent || isIdempotent(req)
+}
+
+// replays reports whether a request may be sent more than once, so its body has to be kept.
+func (p *RetryPolicy) replays() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+func (p *RetryPolicy) shouldRetry(resp *Response, err error) bool {
+	if p.RetryOn != nil {
+		return p.RetryOn(resp, err)
+	}
+	return DefaultRetryOn(resp, err)
+}
+
+// backoff returns the wait before the given attempt (starting at 2), preferring
+// the server's Retry-After when it asks for a longer pause. MaxBackoff caps both.
+func (p *RetryPolicy) backoff(attempt int, resp *Response) time.Duration {
+	wait := float64(p.InitialBackoff) * math.Pow(max(p.Multiplier, 1), float64(attempt-2))
+	if p.MaxBackoff > 0 {
+		wait = min(wait, float64(p.MaxBackoff))
+	}
+	if p.Jitter > 0 {
+		wait -= wait * min(p.Jitter, 1) * rand.Float64()
+	}
+
+	delay := time.Duration(wait)
+	if resp != nil {
+		if after, ok := RetryAfter(resp.Header); ok && after > delay {
+			delay = after
+		}
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	return delay
+}
+
+// RetryAfter parses the Retry-After header given either in seconds or as an HTTP date.
+func RetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
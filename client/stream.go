@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+)
+
+var ErrBodyTooLarge = errors.New("response body exceeds the size limit")
+
+// StreamResponse is a response whose body has not been read yet. The caller owns
+// Body and must close it.
+type StreamResponse struct {
+	Status     string
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadCloser
+	Timing     Timing
+}
+
+func (r StreamResponse) IsSuccess() bool {
+	return r.StatusCode >= 200 && r.StatusCode <= 299
+}
+
+// Err returns an *HTTPError carrying the start of the body for a non-2xx
+// response and nil otherwise. It consumes the body only in the error case.
+func (r StreamResponse) Err() error {
+	if r.IsSuccess() {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(r.Body, errorBodySize))
+	return NewHTTPError(&Response{Status: r.Status, StatusCode: r.StatusCode, Header: r.Header, Body: body})
+}
+
+// Stream sends the request and returns as soon as the response headers arrive,
+// leaving the body unread. A nil payload sends no body.
+func (c *HttpClient) Stream(ctx context.Context, method, urlPath string, payload interface{}, header http.Header, opts ...RequestOption) (*StreamResponse, error) {
+	req, err := c.NewRequest(ctx, method, urlPath, payload, header, append(opts, streamed())...)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.execute(req)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       resp.stream,
+		Timing:     resp.Timing,
+	}, nil
+}
+
+// Download copies the body of a GET response into w and returns the number of
+// bytes written. A non-2xx response is returned as *HTTPError and nothing is written.
+func (c *HttpClient) Download(ctx context.Context, urlPath string, header http.Header, w io.Writer, opts ...RequestOption) (int64, error) {
+	resp, err := c.Stream(ctx, http.MethodGet, urlPath, nil, header, opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := resp.Err(); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, resp.Body)
+}
+
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	return io.ReadAll(newLimitedReader(r, limit))
+}
+
+func limitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{newLimitedReader(body, limit), body}
+}
+
+// limitedReader fails with ErrBodyTooLarge instead of silently stopping at the limit.
+type limitedReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+}
+
+func newLimitedReader(r io.Reader, limit int64) io.Reader {
+	if limit == math.MaxInt64 {
+		return r
+	}
+	return &limitedReader{r: r, limit: limit, remaining: limit}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		if n, _ := l.r.Read(probe[:]); n > 0 {
+			return 0, fmt.Errorf("%w: %d bytes", ErrBodyTooLarge, l.limit)
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHttpClient_Stream(t *testing.T) {
+	payload := strings.Repeat("x", 64*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	resp, err := c.Stream(context.Background(), http.MethodGet, server.URL, nil, nil)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, payload, string(body))
+
+	var buffer bytes.Buffer
+	written, err := c.Download(context.Background(), server.URL, nil, &buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(payload)), written)
+	assert.Equal(t, payload, buffer.String())
+
+	_, err = c.Download(context.Background(), server.URL, nil, io.Discard, WithMaxBodySize(1024))
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+	_, err = c.GetContext(context.Background(), server.URL, nil, nil, WithMaxBodySize(1024))
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+	resp2, err := c.GetContext(context.Background(), server.URL, nil, nil, WithMaxBodySize(int64(len(payload))))
+	assert.NoError(t, err)
+	assert.Equal(t, payload, resp2.StringBody())
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"github.com/huhx/common-go/base"
+	"net/http"
+)
+
+func GetJSON[R any](ctx context.Context, c *HttpClient, url string, params map[string]string, header http.Header) (*R, error) {
+	return decodeJSON[R](c.GetContext(ctx, url, params, header))
+}
+
+func PostJSON[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return decodeJSON[R](c.PostContext(ctx, url, payload, header))
+}
+
+func PutJSON[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return decodeJSON[R](c.PutContext(ctx, url, payload, header))
+}
+
+func PatchJSON[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return decodeJSON[R](c.PatchContext(ctx, url, payload, header))
+}
+
+func DeleteJSON[R any](ctx context.Context, c *HttpClient, url string, header http.Header) (*R, error) {
+	return decodeJSON[R](c.DeleteContext(ctx, url, header))
+}
+
+// GetResult decodes a base.Result envelope and returns its Data, failing with a
+// *ResultError when the envelope carries a non-zero code.
+func GetResult[R any](ctx context.Context, c *HttpClient, url string, params map[string]string, header http.Header) (*R, error) {
+	return unwrapResult(GetJSON[base.Result[R]](ctx, c, url, params, header))
+}
+
+func PostResult[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return unwrapResult(PostJSON[Req, base.Result[R]](ctx, c, url, payload, header))
+}
+
+func PutResult[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return unwrapResult(PutJSON[Req, base.Result[R]](ctx, c, url, payload, header))
+}
+
+func PatchResult[Req, R any](ctx context.Context, c *HttpClient, url string, payload Req, header http.Header) (*R, error) {
+	return unwrapResult(PatchJSON[Req, base.Result[R]](ctx, c, url, payload, header))
+}
+
+func DeleteResult[R any](ctx context.Context, c *HttpClient, url string, header http.Header) (*R, error) {
+	return unwrapResult(DeleteJSON[base.Result[R]](ctx, c, url, header))
+}
+
+func decodeJSON[R any](response *Response, err error) (*R, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+	return JsonBody[R](response)
+}
+
+func unwrapResult[R any](result *base.Result[R], err error) (*R, error) {
+	if err != nil {
+		return nil, err
+	}
+	if result.Code != 0 {
+		return nil, &ResultError{ResultCode: result.Code, ResultMessage: result.Message}
+	}
+	return &result.Data, nil
+}
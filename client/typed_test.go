@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func TestGetJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"huhx"}`))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	result, err := GetJSON[user](context.Background(), &c, server.URL, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &user{Name: "huhx"}, result)
+
+	_, err = GetJSON[user](context.Background(), &c, server.URL+"/missing", nil, nil)
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+func TestPostResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failed" {
+			_, _ = w.Write([]byte(`{"code":1001,"message":"name taken"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"name":"huhx"}}`))
+	}))
+	defer server.Close()
+
+	c := NewHttpClient()
+	result, err := PostResult[user, user](context.Background(), &c, server.URL, user{Name: "huhx"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &user{Name: "huhx"}, result)
+
+	_, err = PostResult[user, user](context.Background(), &c, server.URL+"/failed", user{Name: "huhx"}, nil)
+	assert.Equal(t, &ResultError{ResultCode: 1001, ResultMessage: "name taken"}, err)
+}
@@ -18,6 +18,10 @@ func NewLogger() *Logger {
 	return &Logger{logger: logger}
 }
 
+func NewLoggerWithZap(logger *zap.Logger) *Logger {
+	return &Logger{logger: logger}
+}
+
 func (l *Logger) Info(msg string, fields ...zap.Field) {
 	l.logger.Info(msg, fields...)
 }
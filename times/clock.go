@@ -0,0 +1,92 @@
+package times
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock supplies the current instant to the Now functions of this package.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock reads the wall clock of the machine.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type fixedClock struct {
+	t time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.t
+}
+
+// FixedClock always returns t.
+func FixedClock(t time.Time) Clock {
+	return fixedClock{t}
+}
+
+// ManualClock stands still until it is moved with Set or Advance.
+type ManualClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+type clockHolder struct {
+	Clock
+}
+
+var clock atomic.Value
+
+func init() {
+	clock.Store(clockHolder{SystemClock()})
+}
+
+// SetClock replaces the clock used by LocalDateNow, LocalDateTimeNow, Age and
+// the other Now functions. A nil clock restores the system clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = SystemClock()
+	}
+	clock.Store(clockHolder{c})
+}
+
+// DefaultClock returns the clock set by SetClock, the system clock by default.
+func DefaultClock() Clock {
+	return clock.Load().(clockHolder).Clock
+}
+
+func now() time.Time {
+	return DefaultClock().Now()
+}
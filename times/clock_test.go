@@ -0,0 +1,34 @@
+package times
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 2, 28, 23, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	defer SetClock(nil)
+
+	birthday := LocalDate{Year: 2000, Month: 2, Day: 29}
+	assert.Equal(t, LocalDate{2024, 2, 28}, LocalDateNowIn(time.UTC))
+	assert.Equal(t, 23, birthday.AgeAt(LocalDateNowIn(time.UTC)))
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, LocalDateTime{LocalDate{2024, 2, 29}, LocalTime{}}, LocalDateTimeNowIn(time.UTC))
+	assert.Equal(t, 24, birthday.AgeAt(LocalDateNowIn(time.UTC)))
+	assert.Equal(t, clock.Now(), ZonedDateTimeNow(time.UTC).AsTime())
+
+	SetClock(nil)
+	assert.Equal(t, SystemClock(), DefaultClock())
+}
+
+func TestFixedClock(t *testing.T) {
+	instant := time.Date(2024, 12, 31, 20, 0, 0, 0, time.UTC)
+	clock := FixedClock(instant)
+
+	assert.Equal(t, instant, clock.Now())
+	assert.Equal(t, DateFromTime(instant.In(Timezone())), LocalDateNowFrom(clock))
+	assert.Equal(t, DateTimeFromTime(instant.In(Timezone())), LocalDateTimeNowFrom(clock))
+}
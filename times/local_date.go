@@ -47,19 +47,33 @@ func DateFromDefaultPattern(dateString string) (*LocalDate, error) {
 	}
 }
 
+// Weekday is computed on the calendar alone and does not depend on a zone.
 func (ld LocalDate) Weekday() int {
-	return int(ld.AsTime(timezone).Weekday())
+	return int(ld.AsTime(time.UTC).Weekday())
 }
 
 func LocalDateNow() LocalDate {
-	now := time.Now().In(timezone)
-	return DateFromTime(now)
+	return LocalDateNowIn(Timezone())
+}
+
+// LocalDateNowIn returns today's date as seen in zone.
+func LocalDateNowIn(zone *time.Location) LocalDate {
+	return DateFromTime(now().In(zone))
+}
+
+// LocalDateNowFrom returns today's date according to clock in the default zone.
+func LocalDateNowFrom(clock Clock) LocalDate {
+	return DateFromTime(clock.Now().In(Timezone()))
 }
 
 func (ld LocalDate) Age() int {
-	now := LocalDateNow()
-	age := now.Year - ld.Year
-	if now.Month < ld.Month || (now.Month == ld.Month && now.Day < ld.Day) {
+	return ld.AgeAt(LocalDateNow())
+}
+
+// AgeAt returns the number of full years from ld to the given day.
+func (ld LocalDate) AgeAt(today LocalDate) int {
+	age := today.Year - ld.Year
+	if today.Month < ld.Month || (today.Month == ld.Month && today.Day < ld.Day) {
 		age--
 	}
 	return age
@@ -74,7 +88,7 @@ func (ld LocalDate) EndOfMonth() LocalDate {
 }
 
 func (ld LocalDate) PassDays(date LocalDate) int {
-	return int(ld.AsTime(timezone).Sub(date.AsTime(timezone)) / (24 * time.Hour))
+	return int(ld.AsTime(time.UTC).Sub(date.AsTime(time.UTC)) / (24 * time.Hour))
 }
 
 func (ld LocalDate) ToSolar() LocalDate {
@@ -90,28 +104,91 @@ func (ld LocalDate) ToLunar() LocalDate {
 	return DateFromYMD(lunar.GetYear(), lunar.GetMonth(), lunar.GetDay())
 }
 
+// PlusYear handles February 29 in a common year as set by SetMonthOverflow.
 func (ld LocalDate) PlusYear(year int) LocalDate {
-	newTime := ld.AsTime(timezone).AddDate(year, 0, 0)
-	return DateFromTime(newTime)
+	return ld.PlusMonth(12 * year)
 }
 
+// PlusMonth handles a day missing from the target month as set by SetMonthOverflow.
 func (ld LocalDate) PlusMonth(month int) LocalDate {
-	newTime := ld.AsTime(timezone).AddDate(0, month, 0)
-	return DateFromTime(newTime)
+	res, _ := ld.PlusMonthWith(month, DefaultMonthOverflow())
+	return res
+}
+
+func (ld LocalDate) PlusYearWith(year int, overflow MonthOverflow) (LocalDate, error) {
+	return ld.PlusMonthWith(12*year, overflow)
+}
+
+// PlusMonthWith adds months, handling a day missing from the target month with
+// overflow. Only Reject returns an error.
+func (ld LocalDate) PlusMonthWith(month int, overflow MonthOverflow) (LocalDate, error) {
+	res := ld.plusMonthsClamped(month)
+	if res.Day == ld.Day {
+		return res, nil
+	}
+	switch overflow {
+	case Clamp:
+		return res, nil
+	case Reject:
+		return ld, fmt.Errorf("%w: %s plus %d months", ErrMonthOverflow, ld, month)
+	default:
+		return res.PlusDays(ld.Day - res.Day), nil
+	}
 }
 
+// PlusWeeks and PlusDays work on the calendar in UTC, so DST changes in the
+// default zone cannot shift the resulting date.
 func (ld LocalDate) PlusWeeks(weeks int) LocalDate {
-	newTime := ld.AsTime(timezone).AddDate(0, 0, 7*weeks)
+	newTime := ld.AsTime(time.UTC).AddDate(0, 0, 7*weeks)
 	return DateFromTime(newTime)
 }
 
 func (ld LocalDate) PlusDays(days int) LocalDate {
-	newTime := ld.AsTime(timezone).AddDate(0, 0, days)
+	newTime := ld.AsTime(time.UTC).AddDate(0, 0, days)
 	return DateFromTime(newTime)
 }
 
+func (ld LocalDate) MinusYear(year int) LocalDate {
+	return ld.PlusYear(-year)
+}
+
+func (ld LocalDate) MinusMonth(month int) LocalDate {
+	return ld.PlusMonth(-month)
+}
+
+func (ld LocalDate) MinusWeeks(weeks int) LocalDate {
+	return ld.PlusWeeks(-weeks)
+}
+
+func (ld LocalDate) MinusDays(days int) LocalDate {
+	return ld.PlusDays(-days)
+}
+
+// Plus adds the years and months of period first and then its days. Like
+// java.time it moves a day missing from the target month back to the month's
+// last day, whatever SetMonthOverflow says, so start.Plus(Between(start, end))
+// is end.
+func (ld LocalDate) Plus(period Period) LocalDate {
+	return ld.plusMonthsClamped(period.TotalMonths()).PlusDays(period.Days)
+}
+
+func (ld LocalDate) Minus(period Period) LocalDate {
+	return ld.Plus(period.Negated())
+}
+
+// plusMonthsClamped adds months and moves a day past the end of the target
+// month back to its last day.
+func (ld LocalDate) plusMonthsClamped(months int) LocalDate {
+	total := ld.Year*12 + ld.Month - 1 + months
+	year, month := total/12, total%12+1
+	if month < 1 {
+		year, month = year-1, month+12
+	}
+	return DateFromYMD(year, month, min(ld.Day, daysIn(month, year)))
+}
+
 func (ld LocalDate) Compare(date LocalDate) int {
-	return ld.AsTime(timezone).Compare(date.AsTime(timezone))
+	return ld.AsTime(time.UTC).Compare(date.AsTime(time.UTC))
 }
 
 func (ld LocalDate) Before(date LocalDate) bool {
@@ -7,16 +7,22 @@ import (
 	"time"
 )
 
-var timezone = time.Local
-
 type LocalDateTime struct {
 	date LocalDate
 	time LocalTime
 }
 
 func LocalDateTimeNow() LocalDateTime {
-	now := time.Now().In(timezone)
-	return DateTimeFromTime(now)
+	return LocalDateTimeNowIn(Timezone())
+}
+
+func LocalDateTimeNowIn(zone *time.Location) LocalDateTime {
+	return DateTimeFromTime(now().In(zone))
+}
+
+// LocalDateTimeNowFrom returns the current date-time according to clock in the default zone.
+func LocalDateTimeNowFrom(clock Clock) LocalDateTime {
+	return DateTimeFromTime(clock.Now().In(Timezone()))
 }
 
 func (ldt LocalDateTime) Date() LocalDate {
@@ -58,19 +64,35 @@ func (ldt LocalDateTime) StartOfToday() LocalDateTime {
 }
 
 func (ldt LocalDateTime) PassDays(dateTime LocalDateTime) int {
-	return int(ldt.AsTime(timezone).Sub(dateTime.AsTime(timezone)).Hours() / 24)
+	return ldt.PassDaysIn(dateTime, Timezone())
+}
+
+func (ldt LocalDateTime) PassDaysIn(dateTime LocalDateTime, zone *time.Location) int {
+	return int(ldt.AsTime(zone).Sub(dateTime.AsTime(zone)).Hours() / 24)
 }
 
 func (ldt LocalDateTime) PassHours(dateTime LocalDateTime) int {
-	return int(ldt.AsTime(timezone).Sub(dateTime.AsTime(timezone)).Hours())
+	return ldt.PassHoursIn(dateTime, Timezone())
+}
+
+func (ldt LocalDateTime) PassHoursIn(dateTime LocalDateTime, zone *time.Location) int {
+	return int(ldt.AsTime(zone).Sub(dateTime.AsTime(zone)).Hours())
 }
 
 func (ldt LocalDateTime) PassMinutes(dateTime LocalDateTime) int {
-	return int(ldt.AsTime(timezone).Sub(dateTime.AsTime(timezone)).Minutes())
+	return ldt.PassMinutesIn(dateTime, Timezone())
+}
+
+func (ldt LocalDateTime) PassMinutesIn(dateTime LocalDateTime, zone *time.Location) int {
+	return int(ldt.AsTime(zone).Sub(dateTime.AsTime(zone)).Minutes())
 }
 
 func (ldt LocalDateTime) PassSeconds(dateTime LocalDateTime) int {
-	return int(ldt.AsTime(timezone).Sub(dateTime.AsTime(timezone)).Seconds())
+	return ldt.PassSecondsIn(dateTime, Timezone())
+}
+
+func (ldt LocalDateTime) PassSecondsIn(dateTime LocalDateTime, zone *time.Location) int {
+	return int(ldt.AsTime(zone).Sub(dateTime.AsTime(zone)).Seconds())
 }
 
 func (ldt LocalDateTime) ToSolar() LocalDateTime {
@@ -93,6 +115,16 @@ func (ldt LocalDateTime) PlusMonths(month int) LocalDateTime {
 	return LocalDateTime{localDate, ldt.time}
 }
 
+func (ldt LocalDateTime) PlusYearsWith(year int, overflow MonthOverflow) (LocalDateTime, error) {
+	localDate, err := ldt.date.PlusYearWith(year, overflow)
+	return LocalDateTime{localDate, ldt.time}, err
+}
+
+func (ldt LocalDateTime) PlusMonthsWith(month int, overflow MonthOverflow) (LocalDateTime, error) {
+	localDate, err := ldt.date.PlusMonthWith(month, overflow)
+	return LocalDateTime{localDate, ldt.time}, err
+}
+
 func (ldt LocalDateTime) PlusWeeks(weeks int) LocalDateTime {
 	localDate := ldt.date.PlusWeeks(weeks)
 	return LocalDateTime{localDate, ldt.time}
@@ -103,8 +135,64 @@ func (ldt LocalDateTime) PlusDays(days int) LocalDateTime {
 	return LocalDateTime{localDate, ldt.time}
 }
 
+// PlusHours moves the wall time, rolling over into the next or previous days.
+func (ldt LocalDateTime) PlusHours(hours int) LocalDateTime {
+	return ldt.plusDuration(time.Duration(hours) * time.Hour)
+}
+
+func (ldt LocalDateTime) PlusMinutes(minutes int) LocalDateTime {
+	return ldt.plusDuration(time.Duration(minutes) * time.Minute)
+}
+
+func (ldt LocalDateTime) PlusSeconds(seconds int) LocalDateTime {
+	return ldt.plusDuration(time.Duration(seconds) * time.Second)
+}
+
+func (ldt LocalDateTime) MinusYears(year int) LocalDateTime {
+	return ldt.PlusYears(-year)
+}
+
+func (ldt LocalDateTime) MinusMonths(month int) LocalDateTime {
+	return ldt.PlusMonths(-month)
+}
+
+func (ldt LocalDateTime) MinusWeeks(weeks int) LocalDateTime {
+	return ldt.PlusWeeks(-weeks)
+}
+
+func (ldt LocalDateTime) MinusDays(days int) LocalDateTime {
+	return ldt.PlusDays(-days)
+}
+
+func (ldt LocalDateTime) MinusHours(hours int) LocalDateTime {
+	return ldt.PlusHours(-hours)
+}
+
+func (ldt LocalDateTime) MinusMinutes(minutes int) LocalDateTime {
+	return ldt.PlusMinutes(-minutes)
+}
+
+func (ldt LocalDateTime) MinusSeconds(seconds int) LocalDateTime {
+	return ldt.PlusSeconds(-seconds)
+}
+
+func (ldt LocalDateTime) Plus(period Period) LocalDateTime {
+	return LocalDateTime{ldt.date.Plus(period), ldt.time}
+}
+
+func (ldt LocalDateTime) Minus(period Period) LocalDateTime {
+	return LocalDateTime{ldt.date.Minus(period), ldt.time}
+}
+
+// plusDuration works in UTC so that every day is 24 hours long.
+func (ldt LocalDateTime) plusDuration(d time.Duration) LocalDateTime {
+	res := DateTimeFromTime(ldt.AsTime(time.UTC).Add(d))
+	res.time.Precision = ldt.time.Precision
+	return res
+}
+
 func (ldt LocalDateTime) Compare(dateTim LocalDateTime) int {
-	return ldt.AsTime(timezone).Compare(dateTim.AsTime(timezone))
+	return ldt.AsTime(time.UTC).Compare(dateTim.AsTime(time.UTC))
 }
 
 func (ldt LocalDateTime) Before(dateTim LocalDateTime) bool {
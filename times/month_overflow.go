@@ -0,0 +1,52 @@
+package times
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// MonthOverflow decides what adding months or years does when the day of month
+// does not exist in the target month, such as January 31 plus one month.
+type MonthOverflow int32
+
+const (
+	// RollOver carries the extra days into the next month: 2024-01-31 plus one month is 2024-03-02.
+	RollOver MonthOverflow = iota
+	// Clamp moves to the last day of the target month: 2024-01-31 plus one month is 2024-02-29.
+	Clamp
+	// Reject fails with ErrMonthOverflow. It cannot be the default because
+	// PlusMonth and PlusYear do not return an error.
+	Reject
+)
+
+var ErrMonthOverflow = errors.New("day of month does not exist in the target month")
+
+var monthOverflow atomic.Int32
+
+// SetMonthOverflow changes how PlusMonth, PlusYear and Plus(Period) handle a
+// missing day of month. The default is RollOver, the behaviour of time.AddDate.
+func SetMonthOverflow(overflow MonthOverflow) error {
+	if overflow != RollOver && overflow != Clamp {
+		return fmt.Errorf("month overflow %d cannot be used as the default", overflow)
+	}
+	monthOverflow.Store(int32(overflow))
+	return nil
+}
+
+func DefaultMonthOverflow() MonthOverflow {
+	return MonthOverflow(monthOverflow.Load())
+}
+
+func (o MonthOverflow) String() string {
+	switch o {
+	case RollOver:
+		return "RollOver"
+	case Clamp:
+		return "Clamp"
+	case Reject:
+		return "Reject"
+	default:
+		return fmt.Sprintf("MonthOverflow(%d)", int32(o))
+	}
+}
@@ -0,0 +1,51 @@
+package times
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocalDate_PlusMonthWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     LocalDate
+		months   int
+		overflow MonthOverflow
+		want     LocalDate
+		hasError bool
+	}{
+		{name: "roll over end of january", date: LocalDate{2024, 1, 31}, months: 1, overflow: RollOver, want: LocalDate{2024, 3, 2}},
+		{name: "clamp end of january", date: LocalDate{2024, 1, 31}, months: 1, overflow: Clamp, want: LocalDate{2024, 2, 29}},
+		{name: "reject end of january", date: LocalDate{2024, 1, 31}, months: 1, overflow: Reject, want: LocalDate{2024, 1, 31}, hasError: true},
+		{name: "clamp backwards", date: LocalDate{2024, 3, 31}, months: -1, overflow: Clamp, want: LocalDate{2024, 2, 29}},
+		{name: "clamp across years", date: LocalDate{2024, 12, 31}, months: -22, overflow: Clamp, want: LocalDate{2023, 2, 28}},
+		{name: "existing day is kept", date: LocalDate{2024, 1, 30}, months: 3, overflow: Reject, want: LocalDate{2024, 4, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.date.PlusMonthWith(tt.months, tt.overflow)
+
+			assert.Equal(t, tt.hasError, err != nil)
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrMonthOverflow)
+			}
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestSetMonthOverflow(t *testing.T) {
+	leapDay := LocalDate{2024, 2, 29}
+	assert.Equal(t, RollOver, DefaultMonthOverflow())
+	assert.Equal(t, LocalDate{2025, 3, 1}, leapDay.PlusYear(1))
+
+	assert.NoError(t, SetMonthOverflow(Clamp))
+	defer func() { _ = SetMonthOverflow(RollOver) }()
+
+	assert.Equal(t, LocalDate{2025, 2, 28}, leapDay.PlusYear(1))
+	assert.Equal(t, LocalDate{2024, 2, 29}, LocalDate{2024, 1, 31}.PlusMonth(1))
+	assert.Equal(t, LocalDate{2024, 3, 1}, LocalDate{2024, 1, 31}.Plus(PeriodOf(0, 1, 1)))
+
+	assert.Error(t, SetMonthOverflow(Reject))
+	assert.Equal(t, Clamp, DefaultMonthOverflow())
+}
@@ -0,0 +1,163 @@
+package times
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// OffsetDateTime is a date-time with a fixed offset from UTC, such as +08:00.
+// Unlike ZonedDateTime it knows nothing about DST rules.
+type OffsetDateTime struct {
+	t time.Time
+}
+
+func OffsetDateTimeNow(offset time.Duration) OffsetDateTime {
+	return OffsetFromTime(now().In(fixedZone(offset)))
+}
+
+// OffsetFromTime keeps the instant and the offset t has at that instant.
+func OffsetFromTime(t time.Time) OffsetDateTime {
+	_, offset := t.Zone()
+	return OffsetDateTime{t.In(fixedZone(time.Duration(offset) * time.Second))}
+}
+
+func OffsetFromLocal(ldt LocalDateTime, offset time.Duration) OffsetDateTime {
+	return OffsetDateTime{ldt.AsTime(fixedZone(offset))}
+}
+
+// OffsetFromString parses an RFC 3339 date-time.
+func OffsetFromString(text string) (*OffsetDateTime, error) {
+	t, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return nil, err
+	}
+	odt := OffsetFromTime(t)
+	return &odt, nil
+}
+
+func (odt OffsetDateTime) LocalDateTime() LocalDateTime {
+	return DateTimeFromTime(odt.t)
+}
+
+func (odt OffsetDateTime) Date() LocalDate {
+	return DateFromTime(odt.t)
+}
+
+func (odt OffsetDateTime) Time() LocalTime {
+	return localTimeFromTime(odt.t)
+}
+
+func (odt OffsetDateTime) Offset() time.Duration {
+	_, offset := odt.t.Zone()
+	return time.Duration(offset) * time.Second
+}
+
+func (odt OffsetDateTime) AsTime() time.Time {
+	return odt.t
+}
+
+// AtZone returns the same instant in zone.
+func (odt OffsetDateTime) AtZone(zone *time.Location) ZonedDateTime {
+	return ZonedFromTime(odt.t.In(zone))
+}
+
+// WithOffsetSameInstant returns the same instant seen with another offset.
+func (odt OffsetDateTime) WithOffsetSameInstant(offset time.Duration) OffsetDateTime {
+	return OffsetDateTime{odt.t.In(fixedZone(offset))}
+}
+
+func (odt OffsetDateTime) PlusYears(years int) OffsetDateTime {
+	return odt.withLocal(odt.LocalDateTime().PlusYears(years))
+}
+
+func (odt OffsetDateTime) PlusMonths(months int) OffsetDateTime {
+	return odt.withLocal(odt.LocalDateTime().PlusMonths(months))
+}
+
+func (odt OffsetDateTime) PlusWeeks(weeks int) OffsetDateTime {
+	return odt.withLocal(odt.LocalDateTime().PlusWeeks(weeks))
+}
+
+func (odt OffsetDateTime) PlusDays(days int) OffsetDateTime {
+	return odt.withLocal(odt.LocalDateTime().PlusDays(days))
+}
+
+// Plus adds period to the local date-time, keeping its wall time.
+func (odt OffsetDateTime) Plus(period Period) OffsetDateTime {
+	return odt.withLocal(odt.LocalDateTime().Plus(period))
+}
+
+func (odt OffsetDateTime) Minus(period Period) OffsetDateTime {
+	return odt.Plus(period.Negated())
+}
+
+func (odt OffsetDateTime) PlusDuration(d time.Duration) OffsetDateTime {
+	return OffsetDateTime{odt.t.Add(d)}
+}
+
+func (odt OffsetDateTime) Compare(other OffsetDateTime) int {
+	return odt.t.Compare(other.t)
+}
+
+func (odt OffsetDateTime) Before(other OffsetDateTime) bool {
+	return odt.Compare(other) < 0
+}
+
+func (odt OffsetDateTime) After(other OffsetDateTime) bool {
+	return odt.Compare(other) > 0
+}
+
+// Equal reports whether both denote the same instant, whatever their offsets.
+func (odt OffsetDateTime) Equal(other OffsetDateTime) bool {
+	return odt.Compare(other) == 0
+}
+
+func (odt OffsetDateTime) withLocal(ldt LocalDateTime) OffsetDateTime {
+	return OffsetDateTime{ldt.AsTime(odt.t.Location())}
+}
+
+// String returns RFC 3339 representation of odt, keeping its offset.
+func (odt OffsetDateTime) String() string {
+	return odt.t.Format(time.RFC3339Nano)
+}
+
+func (odt OffsetDateTime) MarshalText() ([]byte, error) {
+	return []byte(odt.String()), nil
+}
+
+func (odt *OffsetDateTime) UnmarshalText(data []byte) error {
+	res, err := OffsetFromString(string(data))
+	if err != nil {
+		return err
+	}
+	*odt = *res
+	return nil
+}
+
+func (odt OffsetDateTime) Value() (driver.Value, error) {
+	return odt.t, nil
+}
+
+func (odt *OffsetDateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		*odt = OffsetFromTime(v)
+		return nil
+	case string:
+		return odt.UnmarshalText([]byte(v))
+	case []byte:
+		return odt.UnmarshalText(v)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T into OffsetDateTime", value)
+	}
+}
+
+func fixedZone(offset time.Duration) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone("", int(offset/time.Second))
+}
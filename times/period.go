@@ -0,0 +1,132 @@
+package times
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Period is an amount of calendar time such as 1 year, 2 months and 3 days.
+// Its fields are kept apart because a month or a year has no fixed number of days.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+func PeriodOf(years, months, days int) Period {
+	return Period{Years: years, Months: months, Days: days}
+}
+
+// PeriodFromString parses an ISO 8601 period such as P1Y2M3D, P4W or -P1M. The
+// units come in the order Y, M, W, D, each at most once.
+func PeriodFromString(text string) (*Period, error) {
+	s, sign := text, 1
+	switch {
+	case strings.HasPrefix(s, "-"):
+		s, sign = s[1:], -1
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if len(s) < 3 || (s[0] != 'P' && s[0] != 'p') {
+		return nil, errors.New(text + " periods are expected to have the format PnYnMnWnD")
+	}
+
+	var p Period
+	s = s[1:]
+	last := -1
+	for s != "" {
+		end := strings.IndexAny(s, "YMWDymwd")
+		if end <= 0 {
+			return nil, errors.New(text + " periods are expected to have the format PnYnMnWnD")
+		}
+		n, err := strconv.Atoi(s[:end])
+		if err != nil {
+			return nil, errors.New(text + " period amounts are expected to be integers")
+		}
+		unit := strings.IndexByte("YMWD", s[end]&^0x20)
+		if unit <= last {
+			return nil, errors.New(text + " period units are expected in the order Y, M, W, D, each at most once")
+		}
+		last = unit
+		switch unit {
+		case 0:
+			p.Years = n
+		case 1:
+			p.Months = n
+		case 2:
+			p.Days += 7 * n
+		case 3:
+			p.Days += n
+		}
+		s = s[end+1:]
+	}
+	if sign < 0 {
+		p = p.Negated()
+	}
+	return &p, nil
+}
+
+// Between returns the period from start to end, exclusive of end. It is
+// negative when end is before start.
+func Between(start, end LocalDate) Period {
+	months := (end.Year*12 + end.Month) - (start.Year*12 + start.Month)
+	days := end.Day - start.Day
+	if months > 0 && days < 0 {
+		months--
+		days = end.PassDays(start.plusMonthsClamped(months))
+	} else if months < 0 && days > 0 {
+		months++
+		days -= daysIn(end.Month, end.Year)
+	}
+	return Period{Years: months / 12, Months: months % 12, Days: days}
+}
+
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+func (p Period) Negated() Period {
+	return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+}
+
+func (p Period) Plus(other Period) Period {
+	return Period{Years: p.Years + other.Years, Months: p.Months + other.Months, Days: p.Days + other.Days}
+}
+
+// TotalMonths returns the years and months of p counted in months.
+func (p Period) TotalMonths() int {
+	return p.Years*12 + p.Months
+}
+
+// String returns ISO 8601 representation of p, P0D for a zero period.
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	var b strings.Builder
+	b.WriteByte('P')
+	for _, part := range []struct {
+		amount int
+		unit   byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if part.amount != 0 {
+			b.WriteString(strconv.Itoa(part.amount))
+			b.WriteByte(part.unit)
+		}
+	}
+	return b.String()
+}
+
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Period) UnmarshalText(data []byte) error {
+	res, err := PeriodFromString(string(data))
+	if err != nil {
+		return err
+	}
+	*p = *res
+	return nil
+}
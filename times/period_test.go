@@ -0,0 +1,78 @@
+package times
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPeriodFromString(t *testing.T) {
+	tests := []struct {
+		text     string
+		want     *Period
+		hasError bool
+	}{
+		{text: "P1Y2M3D", want: &Period{1, 2, 3}},
+		{text: "P2W1D", want: &Period{Days: 15}},
+		{text: "-P1Y-2M", want: &Period{-1, 2, 0}},
+		{text: "P0D", want: &Period{}},
+		{text: "P", hasError: true},
+		{text: "1Y2M", hasError: true},
+		{text: "P1H", hasError: true},
+		{text: "PY", hasError: true},
+		{text: "P1D2Y", hasError: true},
+		{text: "P1Y1Y", hasError: true},
+		{text: "p1y2w", want: &Period{Years: 1, Days: 14}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result, err := PeriodFromString(tt.text)
+
+			assert.Equalf(t, tt.hasError, err != nil, "PeriodFromString(%v)", tt.text)
+			assert.Equalf(t, tt.want, result, "PeriodFromString(%v)", tt.text)
+		})
+	}
+}
+
+func TestPeriod_String(t *testing.T) {
+	assert.Equal(t, "P1Y2M3D", PeriodOf(1, 2, 3).String())
+	assert.Equal(t, "P-1M", PeriodOf(0, -1, 0).String())
+	assert.Equal(t, "P0D", Period{}.String())
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		start LocalDate
+		end   LocalDate
+		want  Period
+	}{
+		{start: LocalDate{2024, 1, 15}, end: LocalDate{2025, 3, 18}, want: Period{1, 2, 3}},
+		{start: LocalDate{2024, 1, 31}, end: LocalDate{2024, 3, 1}, want: Period{0, 1, 1}},
+		{start: LocalDate{2024, 3, 10}, end: LocalDate{2024, 3, 10}, want: Period{}},
+		{start: LocalDate{2024, 3, 18}, end: LocalDate{2023, 1, 15}, want: Period{-1, -2, -3}},
+		{start: LocalDate{2024, 3, 1}, end: LocalDate{2024, 1, 31}, want: Period{0, -1, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.start.String()+"/"+tt.end.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, Between(tt.start, tt.end))
+			assert.Equal(t, tt.end, tt.start.Plus(Between(tt.start, tt.end)))
+		})
+	}
+}
+
+func TestLocalDate_Plus(t *testing.T) {
+	date := LocalDate{2024, 1, 15}
+
+	assert.Equal(t, LocalDate{2025, 3, 18}, date.Plus(PeriodOf(1, 2, 3)))
+	assert.Equal(t, LocalDate{2022, 11, 12}, date.Minus(PeriodOf(1, 2, 3)))
+	assert.Equal(t, LocalDate{2023, 12, 31}, date.MinusDays(15))
+}
+
+func TestLocalDateTime_PlusHours(t *testing.T) {
+	ldt := LocalDateTime{LocalDate{2024, 12, 31}, LocalTime{Hour: 22, Minute: 30, Nanosecond: 500000000, Precision: 3}}
+
+	assert.Equal(t, "2025-01-01T01:30:00.500", ldt.PlusHours(3).String())
+	assert.Equal(t, "2025-01-01T00:00:00.500", ldt.PlusMinutes(90).String())
+	assert.Equal(t, "2024-12-31T22:29:59.500", ldt.MinusSeconds(1).String())
+	assert.Equal(t, "2024-12-30T23:30:00.500", ldt.MinusHours(23).String())
+	assert.Equal(t, "2025-02-01T22:30:00.500", ldt.Plus(PeriodOf(0, 1, 1)).String())
+}
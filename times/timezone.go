@@ -0,0 +1,26 @@
+package times
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var timezone atomic.Pointer[time.Location]
+
+func init() {
+	timezone.Store(time.Local)
+}
+
+// SetTimezone changes the zone used to read the current date and time and to
+// measure elapsed time between local values. A nil zone restores time.Local.
+func SetTimezone(zone *time.Location) {
+	if zone == nil {
+		zone = time.Local
+	}
+	timezone.Store(zone)
+}
+
+// Timezone returns the zone set by SetTimezone, time.Local by default.
+func Timezone() *time.Location {
+	return timezone.Load()
+}
@@ -0,0 +1,27 @@
+package times
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetTimezone(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	SetTimezone(newYork)
+	defer SetTimezone(nil)
+
+	assert.Equal(t, newYork, Timezone())
+	assert.Equal(t, DateFromTime(time.Now().In(newYork)), LocalDateNow())
+
+	// 2024-03-10 is only 23 hours long in New York
+	before := LocalDateTime{LocalDate{2024, 3, 10}, LocalTime{}}
+	after := LocalDateTime{LocalDate{2024, 3, 11}, LocalTime{}}
+	assert.Equal(t, 23, after.PassHours(before))
+	assert.Equal(t, 24, after.PassHoursIn(before, time.UTC))
+	assert.Equal(t, 1, after.Date().PassDays(before.Date()))
+	assert.Equal(t, LocalDate{2024, 3, 11}, before.Date().PlusDays(1))
+
+	SetTimezone(nil)
+	assert.Equal(t, time.Local, Timezone())
+}
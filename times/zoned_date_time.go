@@ -0,0 +1,201 @@
+package times
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// ZonedDateTime is a date-time in a time zone such as Asia/Shanghai. Calendar
+// arithmetic keeps the local wall time across DST changes while clock
+// arithmetic moves the instant.
+type ZonedDateTime struct {
+	t time.Time
+}
+
+func ZonedDateTimeNow(zone *time.Location) ZonedDateTime {
+	return ZonedFromTime(now().In(zone))
+}
+
+func ZonedFromTime(t time.Time) ZonedDateTime {
+	return ZonedDateTime{t}
+}
+
+// ZonedFromLocal places ldt in zone. A wall time skipped by a DST gap is moved
+// forward by the length of the gap; an ambiguous one takes the earlier offset.
+func ZonedFromLocal(ldt LocalDateTime, zone *time.Location) ZonedDateTime {
+	t := ldt.AsTime(zone)
+	if DateTimeFromTime(t).AsTime(time.UTC).Equal(ldt.AsTime(time.UTC)) {
+		return ZonedDateTime{t}
+	}
+	// the wall time falls in a gap; time.Date may resolve it on either side, so
+	// apply the offset in effect before the transition, which lands after the gap
+	return ZonedDateTime{ldt.AsTime(time.UTC).Add(-offsetBeforeGap(t)).In(zone)}
+}
+
+// offsetBeforeGap returns the offset before the transition next to t. Clocks
+// move forward at a gap, so that is the smaller of the offsets on both sides.
+func offsetBeforeGap(t time.Time) time.Duration {
+	_, offset := t.Zone()
+	start, end := t.ZoneBounds()
+	for _, neighbour := range []time.Time{start.Add(-time.Nanosecond), end} {
+		if !neighbour.IsZero() {
+			_, other := neighbour.In(t.Location()).Zone()
+			offset = min(offset, other)
+		}
+	}
+	return time.Duration(offset) * time.Second
+}
+
+// ZonedFromString parses an RFC 3339 date-time. The zone is the fixed offset
+// given in the text.
+func ZonedFromString(text string) (*ZonedDateTime, error) {
+	t, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return nil, err
+	}
+	zdt := ZonedFromTime(t)
+	return &zdt, nil
+}
+
+func (zdt ZonedDateTime) LocalDateTime() LocalDateTime {
+	return DateTimeFromTime(zdt.t)
+}
+
+func (zdt ZonedDateTime) Date() LocalDate {
+	return DateFromTime(zdt.t)
+}
+
+func (zdt ZonedDateTime) Time() LocalTime {
+	return localTimeFromTime(zdt.t)
+}
+
+func (zdt ZonedDateTime) Location() *time.Location {
+	return zdt.t.Location()
+}
+
+// Offset returns the offset from UTC in effect at this instant.
+func (zdt ZonedDateTime) Offset() time.Duration {
+	_, offset := zdt.t.Zone()
+	return time.Duration(offset) * time.Second
+}
+
+func (zdt ZonedDateTime) ToOffsetDateTime() OffsetDateTime {
+	return OffsetFromTime(zdt.t)
+}
+
+func (zdt ZonedDateTime) AsTime() time.Time {
+	return zdt.t
+}
+
+// WithZoneSameInstant returns the same instant seen from zone.
+func (zdt ZonedDateTime) WithZoneSameInstant(zone *time.Location) ZonedDateTime {
+	return ZonedDateTime{zdt.t.In(zone)}
+}
+
+// WithZoneSameLocal returns the same wall time in zone, which is a different instant.
+func (zdt ZonedDateTime) WithZoneSameLocal(zone *time.Location) ZonedDateTime {
+	return ZonedFromLocal(zdt.LocalDateTime(), zone)
+}
+
+func (zdt ZonedDateTime) PlusYears(years int) ZonedDateTime {
+	return zdt.withLocal(zdt.LocalDateTime().PlusYears(years))
+}
+
+func (zdt ZonedDateTime) PlusMonths(months int) ZonedDateTime {
+	return zdt.withLocal(zdt.LocalDateTime().PlusMonths(months))
+}
+
+func (zdt ZonedDateTime) PlusWeeks(weeks int) ZonedDateTime {
+	return zdt.withLocal(zdt.LocalDateTime().PlusWeeks(weeks))
+}
+
+func (zdt ZonedDateTime) PlusDays(days int) ZonedDateTime {
+	return zdt.withLocal(zdt.LocalDateTime().PlusDays(days))
+}
+
+func (zdt ZonedDateTime) PlusHours(hours int) ZonedDateTime {
+	return zdt.PlusDuration(time.Duration(hours) * time.Hour)
+}
+
+func (zdt ZonedDateTime) PlusMinutes(minutes int) ZonedDateTime {
+	return zdt.PlusDuration(time.Duration(minutes) * time.Minute)
+}
+
+func (zdt ZonedDateTime) PlusSeconds(seconds int) ZonedDateTime {
+	return zdt.PlusDuration(time.Duration(seconds) * time.Second)
+}
+
+// Plus adds period to the local date-time, keeping its wall time.
+func (zdt ZonedDateTime) Plus(period Period) ZonedDateTime {
+	return zdt.withLocal(zdt.LocalDateTime().Plus(period))
+}
+
+func (zdt ZonedDateTime) Minus(period Period) ZonedDateTime {
+	return zdt.Plus(period.Negated())
+}
+
+// PlusDuration moves the instant, so a day of 24h may land on a different wall
+// time across a DST change; use PlusDays to keep the wall time.
+func (zdt ZonedDateTime) PlusDuration(d time.Duration) ZonedDateTime {
+	return ZonedDateTime{zdt.t.Add(d)}
+}
+
+func (zdt ZonedDateTime) Compare(other ZonedDateTime) int {
+	return zdt.t.Compare(other.t)
+}
+
+func (zdt ZonedDateTime) Before(other ZonedDateTime) bool {
+	return zdt.Compare(other) < 0
+}
+
+func (zdt ZonedDateTime) After(other ZonedDateTime) bool {
+	return zdt.Compare(other) > 0
+}
+
+// Equal reports whether both denote the same instant, whatever their zones.
+func (zdt ZonedDateTime) Equal(other ZonedDateTime) bool {
+	return zdt.Compare(other) == 0
+}
+
+func (zdt ZonedDateTime) withLocal(ldt LocalDateTime) ZonedDateTime {
+	return ZonedFromLocal(ldt, zdt.t.Location())
+}
+
+// String returns RFC 3339 representation of zdt, keeping its offset.
+func (zdt ZonedDateTime) String() string {
+	return zdt.t.Format(time.RFC3339Nano)
+}
+
+func (zdt ZonedDateTime) MarshalText() ([]byte, error) {
+	return []byte(zdt.String()), nil
+}
+
+func (zdt *ZonedDateTime) UnmarshalText(data []byte) error {
+	res, err := ZonedFromString(string(data))
+	if err != nil {
+		return err
+	}
+	*zdt = *res
+	return nil
+}
+
+func (zdt ZonedDateTime) Value() (driver.Value, error) {
+	return zdt.t, nil
+}
+
+func (zdt *ZonedDateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		zdt.t = v
+		return nil
+	case string:
+		return zdt.UnmarshalText([]byte(v))
+	case []byte:
+		return zdt.UnmarshalText(v)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T into ZonedDateTime", value)
+	}
+}
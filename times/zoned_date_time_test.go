@@ -0,0 +1,101 @@
+package times
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	zone, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	return zone
+}
+
+func TestZonedDateTime_DST(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	// the day before clocks spring forward on 2024-03-10
+	zdt := ZonedFromLocal(LocalDateTime{LocalDate{2024, 3, 9}, LocalTime{Hour: 9}}, newYork)
+
+	tests := []struct {
+		name string
+		got  ZonedDateTime
+		want string
+	}{
+		{name: "plus days keeps the wall time", got: zdt.PlusDays(1), want: "2024-03-10T09:00:00-04:00"},
+		{name: "plus hours keeps the elapsed time", got: zdt.PlusHours(24), want: "2024-03-10T10:00:00-04:00"},
+		{name: "plus months crosses the change", got: zdt.PlusMonths(1), want: "2024-04-09T09:00:00-04:00"},
+		{name: "wall time in the gap moves forward", got: ZonedFromLocal(LocalDateTime{LocalDate{2024, 3, 10}, LocalTime{Hour: 2, Minute: 30}}, newYork), want: "2024-03-10T03:30:00-04:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.String())
+		})
+	}
+	assert.Equal(t, -5*time.Hour, zdt.Offset())
+}
+
+func TestZonedFromLocal_HalfHourGap(t *testing.T) {
+	lordHowe := mustLoadLocation(t, "Australia/Lord_Howe")
+	// clocks move from 02:00 +10:30 to 02:30 +11:00 on 2024-10-06
+	zdt := ZonedFromLocal(LocalDateTime{LocalDate{2024, 10, 6}, LocalTime{Hour: 2, Minute: 15}}, lordHowe)
+
+	assert.Equal(t, "2024-10-06T02:45:00+11:00", zdt.String())
+}
+
+func TestZonedDateTime_Zones(t *testing.T) {
+	shanghai := mustLoadLocation(t, "Asia/Shanghai")
+	zdt := ZonedFromLocal(LocalDateTime{LocalDate{2024, 1, 1}, LocalTime{Hour: 6}}, shanghai)
+
+	utc := zdt.WithZoneSameInstant(time.UTC)
+	assert.Equal(t, "2023-12-31T22:00:00Z", utc.String())
+	assert.Equal(t, LocalDate{2023, 12, 31}, utc.Date())
+	assert.True(t, utc.Equal(zdt))
+
+	sameLocal := zdt.WithZoneSameLocal(time.UTC)
+	assert.Equal(t, "2024-01-01T06:00:00Z", sameLocal.String())
+	assert.True(t, zdt.Before(sameLocal))
+
+	odt := zdt.ToOffsetDateTime()
+	assert.Equal(t, 8*time.Hour, odt.Offset())
+	assert.Equal(t, "2024-01-01T06:00:00+08:00", odt.String())
+	assert.Equal(t, shanghai, odt.AtZone(shanghai).Location())
+}
+
+func TestZonedDateTime_Marshal(t *testing.T) {
+	type event struct {
+		Zoned  ZonedDateTime  `json:"zoned"`
+		Offset OffsetDateTime `json:"offset"`
+	}
+	data := `{"zoned":"2024-05-01T10:30:00.5+08:00","offset":"2024-05-01T10:30:00-03:00"}`
+
+	var e event
+	assert.NoError(t, json.Unmarshal([]byte(data), &e))
+	assert.Equal(t, 8*time.Hour, e.Zoned.Offset())
+	assert.Equal(t, LocalTime{Hour: 10, Minute: 30, Nanosecond: 500000000}, e.Zoned.Time())
+	assert.Equal(t, -3*time.Hour, e.Offset.Offset())
+
+	out, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.JSONEq(t, data, string(out))
+
+	value, err := e.Offset.Value()
+	assert.NoError(t, err)
+	var scanned OffsetDateTime
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, e.Offset.String(), scanned.String())
+
+	assert.Error(t, scanned.Scan(42))
+	assert.Error(t, e.Zoned.UnmarshalText([]byte("2024-05-01T10:30:00")))
+}
+
+func TestOffsetDateTime_Plus(t *testing.T) {
+	odt := OffsetFromLocal(LocalDateTime{LocalDate{2024, 1, 31}, LocalTime{Hour: 23}}, 5*time.Hour+30*time.Minute)
+
+	assert.Equal(t, "2024-02-01T23:00:00+05:30", odt.PlusDays(1).String())
+	assert.Equal(t, "2024-02-01T00:00:00+05:30", odt.PlusDuration(time.Hour).String())
+	assert.Equal(t, "2024-01-31T17:30:00Z", odt.WithOffsetSameInstant(0).String())
+}